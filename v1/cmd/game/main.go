@@ -13,11 +13,50 @@ func init() {
 
 func main() {
 	game.InitImages()
+	// Every data file falls back to its built-in default when it is missing
+	// or invalid
 	cfg, err := game.LoadConfig(game.ConfigFile)
 	if err != nil {
 		log.Println("using default config:", err)
+		cfg = game.DefaultConfig
 	}
 	g := game.NewGameWithConfig(cfg)
+	gameMap, err := game.LoadMap(game.MapFile)
+	if err != nil {
+		log.Println("using default map:", err)
+		gameMap = game.DefaultMap()
+	}
+	g.SetMap(gameMap)
+	bosses, err := game.LoadBosses(game.BossesFile)
+	if err != nil {
+		log.Println("using default bosses:", err)
+		bosses = game.DefaultBosses()
+	}
+	g.SetBosses(bosses)
+	affixes, err := game.LoadAffixes(game.AffixesFile)
+	if err != nil {
+		log.Println("using default affixes:", err)
+		affixes = game.DefaultAffixes()
+	}
+	g.SetAffixes(affixes)
+	roster, err := game.LoadRoster(game.UnitsFile)
+	if err != nil {
+		log.Println("using default units:", err)
+		roster = game.DefaultRoster()
+	}
+	g.SetRoster(roster)
+	waves, err := game.LoadWaveScript(game.WavesFile)
+	if err == nil {
+		err = waves.CheckLanes(gameMap)
+	}
+	if err == nil {
+		err = waves.CheckBosses(bosses)
+	}
+	if err != nil {
+		log.Println("using default waves:", err)
+		waves = game.DefaultWaveScript(cfg)
+	}
+	g.SetWaveScript(waves)
 	if err := ebiten.RunGame(g); err != nil {
 		log.Fatal(err)
 	}
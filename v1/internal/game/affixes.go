@@ -0,0 +1,296 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"os"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// AffixesFile is the default path for mob affix data.
+const AffixesFile = "affixes.yaml"
+
+// AffixDef is a data-defined mob modifier. Every effect field is optional;
+// an affix may combine several of them.
+type AffixDef struct {
+	ID      string   `yaml:"id"`
+	Name    string   `yaml:"name"`
+	Color   [3]uint8 `yaml:"color"`    // tint applied to affixed mobs
+	MinWave int      `yaml:"min_wave"` // first wave the affix can roll on
+	Weight  int      `yaml:"weight"`   // relative roll weight, 0 means 1
+
+	HPMult      float64 `yaml:"hp_mult"`      // health multiplier
+	RewardMult  float64 `yaml:"reward_mult"`  // gold and score multiplier on death
+	Regen       float64 `yaml:"regen"`        // health regained per second
+	Split       int     `yaml:"split"`        // half-health copies spawned on death
+	HasteRadius float64 `yaml:"haste_radius"` // pixels of the speed aura
+	HasteMult   float64 `yaml:"haste_mult"`   // speed multiplier for mobs in the aura
+	ReflectHeal int     `yaml:"reflect_heal"` // health regained per mistyped letter
+}
+
+// tint returns the affix colour.
+func (a *AffixDef) tint() color.RGBA {
+	return color.RGBA{a.Color[0], a.Color[1], a.Color[2], 255}
+}
+
+// AffixRoll controls how often affixes appear as waves get deeper.
+type AffixRoll struct {
+	FromWave   int     `yaml:"from_wave"`   // first wave any affix can roll
+	BaseChance float64 `yaml:"base_chance"` // chance per slot on FromWave
+	PerWave    float64 `yaml:"per_wave"`    // chance added every wave after
+	MaxChance  float64 `yaml:"max_chance"`
+	SlotEvery  int     `yaml:"slot_every"` // waves per extra affix slot, 0 for one slot
+}
+
+// AffixBook holds every affix definition and the roll rules.
+type AffixBook struct {
+	Affixes []AffixDef `yaml:"affixes"`
+	Roll    AffixRoll  `yaml:"roll"`
+}
+
+// LoadAffixes parses a YAML file into an AffixBook and validates it.
+func LoadAffixes(path string) (*AffixBook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAffixes(data)
+}
+
+// ParseAffixes parses YAML affix data and validates it.
+func ParseAffixes(data []byte) (*AffixBook, error) {
+	var b AffixBook
+	if err := yaml.UnmarshalStrict(data, &b); err != nil {
+		return nil, err
+	}
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// DefaultAffixes returns the built-in affixes.
+func DefaultAffixes() *AffixBook {
+	b := &AffixBook{
+		Affixes: []AffixDef{
+			{ID: "elite", Name: "Elite", Color: [3]uint8{255, 215, 0}, MinWave: 5, Weight: 3, HPMult: 2, RewardMult: 3},
+			{ID: "regenerating", Name: "Regenerating", Color: [3]uint8{80, 220, 80}, MinWave: 8, Weight: 2, Regen: 0.5},
+			{ID: "splitting", Name: "Splitting", Color: [3]uint8{200, 120, 255}, MinWave: 10, Weight: 2, Split: 2},
+			{ID: "hasted", Name: "Hasted", Color: [3]uint8{80, 200, 255}, MinWave: 12, Weight: 1, HasteRadius: 96, HasteMult: 1.3},
+			{ID: "reflective", Name: "Reflective", Color: [3]uint8{255, 90, 90}, MinWave: 15, Weight: 1, ReflectHeal: 1},
+		},
+		Roll: AffixRoll{FromWave: 5, BaseChance: 0.05, PerWave: 0.01, MaxChance: 0.5, SlotEvery: 10},
+	}
+	if err := b.validate(); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate checks ids and value ranges.
+func (b *AffixBook) validate() error {
+	if len(b.Affixes) == 0 {
+		return fmt.Errorf("no affixes defined")
+	}
+	seen := map[string]bool{}
+	for i, a := range b.Affixes {
+		if a.ID == "" {
+			return fmt.Errorf("affix %d has no id", i+1)
+		}
+		if seen[a.ID] {
+			return fmt.Errorf("duplicate affix %s", a.ID)
+		}
+		seen[a.ID] = true
+		if a.MinWave < 0 || a.Weight < 0 || a.HPMult < 0 || a.RewardMult < 0 || a.Regen < 0 ||
+			a.Split < 0 || a.HasteRadius < 0 || a.HasteMult < 0 || a.ReflectHeal < 0 {
+			return fmt.Errorf("affix %s: negative value", a.ID)
+		}
+		if (a.HasteRadius > 0) != (a.HasteMult > 0) {
+			return fmt.Errorf("affix %s: haste needs both a radius and a multiplier", a.ID)
+		}
+	}
+	r := b.Roll
+	if r.FromWave < 1 {
+		return fmt.Errorf("roll from_wave must be at least 1")
+	}
+	if r.BaseChance < 0 || r.PerWave < 0 || r.SlotEvery < 0 || r.MaxChance < 0 || r.MaxChance > 1 {
+		return fmt.Errorf("roll values out of range")
+	}
+	return nil
+}
+
+// chance returns the per-slot affix chance on the given wave, scaled by the
+// difficulty multiplier.
+func (b *AffixBook) chance(wave int, scale float64) float64 {
+	r := b.Roll
+	if wave < r.FromWave {
+		return 0
+	}
+	c := (r.BaseChance + r.PerWave*float64(wave-r.FromWave)) * scale
+	return math.Min(c, r.MaxChance)
+}
+
+// slots returns how many affixes a mob on the given wave may carry.
+func (b *AffixBook) slots(wave int) int {
+	if b.Roll.SlotEvery <= 0 {
+		return 1
+	}
+	return 1 + (wave-1)/b.Roll.SlotEvery
+}
+
+// RollAffixes picks the affixes for a mob spawned on the given wave. Each slot
+// rolls against the wave's chance and draws a weighted affix the mob does not
+// already have.
+func (b *AffixBook) RollAffixes(wave int, scale float64) []*AffixDef {
+	if b == nil {
+		return nil
+	}
+	chance := b.chance(wave, scale)
+	var out []*AffixDef
+	for slot := 0; slot < b.slots(wave); slot++ {
+		if rand.Float64() >= chance {
+			continue
+		}
+		if a := b.pick(wave, out); a != nil {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// pick draws a weighted affix available on the wave and not in have.
+func (b *AffixBook) pick(wave int, have []*AffixDef) *AffixDef {
+	var pool []*AffixDef
+	total := 0
+	for i := range b.Affixes {
+		a := &b.Affixes[i]
+		if wave < a.MinWave || hasAffix(have, a.ID) {
+			continue
+		}
+		pool = append(pool, a)
+		total += affixWeight(a)
+	}
+	if total == 0 {
+		return nil
+	}
+	n := rand.Intn(total)
+	for _, a := range pool {
+		if n -= affixWeight(a); n < 0 {
+			return a
+		}
+	}
+	return nil
+}
+
+// affixWeight returns the roll weight, treating 0 as 1.
+func affixWeight(a *AffixDef) int {
+	if a.Weight == 0 {
+		return 1
+	}
+	return a.Weight
+}
+
+// hasAffix reports whether the list contains the affix id.
+func hasAffix(list []*AffixDef, id string) bool {
+	for _, a := range list {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// applyAffixes gives a mob its affixes and their one-off effects.
+func (m *Mob) applyAffixes(affixes []*AffixDef) {
+	m.affixes = affixes
+	m.maxHealth = m.health
+	for _, a := range affixes {
+		if a.HPMult > 0 {
+			m.health = int(math.Ceil(float64(m.health) * a.HPMult))
+			m.maxHealth = m.health
+		}
+		if a.Regen > 0 {
+			m.regen += a.Regen
+		}
+	}
+}
+
+// rewardMultiplier returns the combined reward multiplier of the affixes.
+func (m *Mob) rewardMultiplier() float64 {
+	mult := 1.0
+	for _, a := range m.affixes {
+		if a.RewardMult > 0 {
+			mult *= a.RewardMult
+		}
+	}
+	return mult
+}
+
+// Affixes returns the mob's affixes.
+func (m *Mob) Affixes() []*AffixDef { return m.affixes }
+
+// SetAffixes replaces the affix book used for later spawns.
+func (g *Game) SetAffixes(b *AffixBook) {
+	if b != nil {
+		g.affixes = b
+	}
+}
+
+// applyHasteAuras sets every mob's speed multiplier from hasted mobs nearby.
+// Auras do not stack; the strongest one applies.
+func (g *Game) applyHasteAuras() {
+	for _, e := range g.mobs {
+		m, ok := asMob(e)
+		if !ok {
+			continue
+		}
+		m.hasteMult = 1
+		for _, o := range g.mobs {
+			src, ok := asMob(o)
+			if !ok || !src.Alive() {
+				continue
+			}
+			for _, a := range src.affixes {
+				if a.HasteMult > m.hasteMult && math.Hypot(src.pos.X-m.pos.X, src.pos.Y-m.pos.Y) <= a.HasteRadius {
+					m.hasteMult = a.HasteMult
+				}
+			}
+		}
+	}
+}
+
+// reflectMistype heals every reflective mob after a mistyped letter.
+func (g *Game) reflectMistype() {
+	for _, e := range g.mobs {
+		m, ok := asMob(e)
+		if !ok || !m.Alive() {
+			continue
+		}
+		for _, a := range m.affixes {
+			if a.ReflectHeal > 0 {
+				m.health = min(m.health+a.ReflectHeal, m.maxHealth)
+			}
+		}
+	}
+}
+
+// splitMob spawns the copies of a dead splitting mob. Copies have half its
+// maximum health and no affixes, so they do not split again.
+func (g *Game) splitMob(m *Mob) {
+	if m.health > 0 {
+		return // removed at the base rather than killed
+	}
+	for _, a := range m.affixes {
+		for i := 0; i < a.Split; i++ {
+			hp := max(1, m.maxHealth/2)
+			y := m.pos.Y + float64(i*2-1)*float64(TileSize)/2
+			c := newMobOfType(m.mobType, m.pos.X, y, m.target, hp, m.speed)
+			c.route.waypoints = m.route.waypoints
+			g.routeMob(c)
+			g.mobs = append(g.mobs, c)
+		}
+	}
+}
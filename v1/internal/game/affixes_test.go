@@ -0,0 +1,160 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+// affix returns the default affix with the given id.
+func affix(t *testing.T, id string) *AffixDef {
+	t.Helper()
+	b := DefaultAffixes()
+	for i := range b.Affixes {
+		if b.Affixes[i].ID == id {
+			return &b.Affixes[i]
+		}
+	}
+	t.Fatalf("no affix %s", id)
+	return nil
+}
+
+func TestAffixRollsScaleWithWave(t *testing.T) {
+	b := DefaultAffixes()
+	if c := b.chance(1, 1); c != 0 {
+		t.Errorf("no affixes before from_wave, chance %v", c)
+	}
+	if b.chance(20, 1) <= b.chance(10, 1) {
+		t.Errorf("affix chance should grow with wave depth")
+	}
+	if b.chance(20, 2) <= b.chance(20, 1) {
+		t.Errorf("difficulty scale should raise the chance")
+	}
+	if c := b.chance(500, 10); c != b.Roll.MaxChance {
+		t.Errorf("chance should be capped at %v, got %v", b.Roll.MaxChance, c)
+	}
+	if b.slots(25) != 3 {
+		t.Errorf("wave 25 should have 3 affix slots, got %d", b.slots(25))
+	}
+	for i := 0; i < 100; i++ {
+		for _, a := range b.RollAffixes(6, 100) {
+			if a.MinWave > 6 {
+				t.Fatalf("%s rolled before its min_wave", a.ID)
+			}
+		}
+	}
+}
+
+func TestEliteAffix(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, affixes: DefaultAffixes(), affixScale: 1}
+	m := NewMob(500, 500, g.base, 5, 1)
+	m.applyAffixes([]*AffixDef{affix(t, "elite")})
+	if m.health != 10 || m.maxHealth != 10 {
+		t.Fatalf("elite should double health, got %d/%d", m.health, m.maxHealth)
+	}
+	if m.rewardMultiplier() != 3 {
+		t.Errorf("elite reward multiplier %v, want 3", m.rewardMultiplier())
+	}
+}
+
+func TestRegeneratingAffix(t *testing.T) {
+	m := NewMob(500, 500, nil, 10, 0)
+	m.applyAffixes([]*AffixDef{affix(t, "regenerating")})
+	m.health = 5
+	m.Update(4)
+	if m.health != 7 {
+		t.Errorf("regen 0.5/s over 4s should heal 2, hp %d", m.health)
+	}
+	m.Update(100)
+	if m.health != 10 {
+		t.Errorf("regen should stop at max health, hp %d", m.health)
+	}
+}
+
+func TestSplittingAffix(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, affixes: DefaultAffixes(), affixScale: 1}
+	m := NewMob(500, 500, g.base, 8, 1)
+	m.applyAffixes([]*AffixDef{affix(t, "splitting")})
+	m.Damage(8)
+	g.splitMob(m)
+	if len(g.mobs) != 2 {
+		t.Fatalf("splitting mob should leave 2 copies, got %d", len(g.mobs))
+	}
+	for _, e := range g.mobs {
+		c := e.(*Mob)
+		if c.health != 4 || len(c.affixes) != 0 {
+			t.Errorf("copy should have half health and no affixes, got %d %v", c.health, c.affixes)
+		}
+	}
+
+	g.mobs = nil
+	k := NewMob(500, 500, g.base, 8, 1)
+	k.applyAffixes([]*AffixDef{affix(t, "splitting")})
+	k.kill()
+	g.splitMob(k)
+	if len(g.mobs) != 0 {
+		t.Errorf("mobs removed at the base should not split")
+	}
+}
+
+func TestHastedAura(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, affixes: DefaultAffixes(), affixScale: 1}
+	src := NewMob(500, 500, g.base, 5, 1)
+	src.applyAffixes([]*AffixDef{affix(t, "hasted")})
+	near := NewMob(550, 500, g.base, 5, 1)
+	far := NewMob(900, 500, g.base, 5, 1)
+	g.mobs = []Enemy{src, near, far}
+	g.applyHasteAuras()
+	if near.hasteMult != 1.3 || src.hasteMult != 1.3 {
+		t.Errorf("mobs in the aura should be hastened, got %v %v", near.hasteMult, src.hasteMult)
+	}
+	if far.hasteMult != 1 {
+		t.Errorf("mobs outside the aura should keep their speed, got %v", far.hasteMult)
+	}
+}
+
+func TestReflectiveAffix(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, affixes: DefaultAffixes(), affixScale: 1}
+	m := NewMob(500, 500, g.base, 5, 1)
+	m.applyAffixes([]*AffixDef{affix(t, "reflective")})
+	m.Damage(3)
+	g.mobs = []Enemy{m}
+	g.MistypeFeedback()
+	if m.health != 3 {
+		t.Errorf("a mistype should heal a reflective mob, hp %d", m.health)
+	}
+	for i := 0; i < 5; i++ {
+		g.MistypeFeedback()
+	}
+	if m.health != 5 {
+		t.Errorf("reflection should not exceed max health, hp %d", m.health)
+	}
+}
+
+func TestAffixValidation(t *testing.T) {
+	roll := "roll: {from_wave: 1, base_chance: 0.1, max_chance: 0.5}\n"
+	cases := map[string]string{
+		"no affixes": "affixes: []\n" + roll,
+		"no id":      "affixes:\n  - {name: x}\n" + roll,
+		"duplicate":  "affixes:\n  - {id: a}\n  - {id: a}\n" + roll,
+		"negative":   "affixes:\n  - {id: a, hp_mult: -1}\n" + roll,
+		"haste":      "affixes:\n  - {id: a, haste_radius: 10}\n" + roll,
+		"from wave":  "affixes:\n  - {id: a}\nroll: {from_wave: 0}\n",
+		"max chance": "affixes:\n  - {id: a}\nroll: {from_wave: 1, max_chance: 2}\n",
+		"unknown":    "affixes:\n  - {id: a, vampiric: 1}\n" + roll,
+	}
+	for name, data := range cases {
+		if _, err := ParseAffixes([]byte(data)); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestAffixesFileMatchesDefault(t *testing.T) {
+	b, err := LoadAffixes("../../" + AffixesFile)
+	if err != nil {
+		t.Fatalf("load %s: %v", AffixesFile, err)
+	}
+	if !reflect.DeepEqual(b, DefaultAffixes()) {
+		t.Errorf("%s should mirror DefaultAffixes", AffixesFile)
+	}
+}
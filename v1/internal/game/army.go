@@ -0,0 +1,145 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// shoutKey starts a shout during play. Letters typed after it go to the
+// shout instead of the queue until the shout is complete or mistyped, so a
+// shout costs the queue the time spent typing it.
+const shoutKey = '!'
+
+// shouts maps the words typed after shoutKey to army orders.
+var shouts = map[string]string{
+	"go":   "charge",
+	"hold": "hold",
+	"back": "retreat",
+	"boss": "focus boss",
+}
+
+// orderArmy gives the army an order from command mode or a shout:
+// charge, hold [col], retreat, rally <col>, focus boss or squad <unit|all>.
+// It reports whether the order was understood and carried out.
+func (g *Game) orderArmy(args []string) bool {
+	m := g.military
+	if m == nil || len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "charge":
+		m.Charge()
+	case "hold":
+		if len(args) < 2 {
+			m.HoldPosition()
+			return true
+		}
+		p, ok := g.columnPoint(args[1])
+		if !ok {
+			return false
+		}
+		m.Hold(p)
+	case "retreat":
+		if g.base == nil {
+			return false
+		}
+		m.Retreat(g.base.pos)
+	case "rally":
+		if len(args) < 2 {
+			return false
+		}
+		p, ok := g.columnPoint(args[1])
+		if !ok {
+			return false
+		}
+		m.SetRally(p)
+	case "focus":
+		if len(args) < 2 || args[1] != "boss" {
+			return false
+		}
+		b := g.activeBoss()
+		if b == nil {
+			return false
+		}
+		m.Focus(b)
+	case "squad":
+		if len(args) < 2 {
+			return false
+		}
+		kind := strings.TrimSuffix(args[1], "s")
+		if kind != "all" && kind != heroKind && (g.barracks == nil || g.barracks.Roster().Stats(kind) == nil) {
+			return false
+		}
+		m.SetSquad(kind)
+	default:
+		return false
+	}
+	return true
+}
+
+// columnPoint returns the centre of the tile in the given grid column on the
+// base's row.
+func (g *Game) columnPoint(arg string) (Point, bool) {
+	col, err := strconv.Atoi(arg)
+	if err != nil || g.base == nil {
+		return Point{}, false
+	}
+	row := (int(g.base.pos.Y) - TopMargin) / TileSize
+	if !inGrid(col, row) {
+		return Point{}, false
+	}
+	return tileCenter([2]int{col, row}), true
+}
+
+// typeShouts feeds typed letters to the current shout and returns the
+// letters left for the queue. Typing shoutKey starts a shout; a mistyped
+// letter drops it.
+func (g *Game) typeShouts(typed []rune) []rune {
+	var rest []rune
+	for _, r := range typed {
+		switch {
+		case g.shouting:
+			g.shoutTyped += string(unicode.ToLower(r))
+			if order, ok := shouts[g.shoutTyped]; ok {
+				g.orderArmy(strings.Fields(order))
+				g.shouting, g.shoutTyped = false, ""
+			} else if !shoutPrefix(g.shoutTyped) {
+				g.shouting, g.shoutTyped = false, ""
+			}
+		case r == shoutKey:
+			g.shouting, g.shoutTyped = true, ""
+		default:
+			rest = append(rest, r)
+		}
+	}
+	return rest
+}
+
+// shoutPrefix reports whether s starts any shout.
+func shoutPrefix(s string) bool {
+	for w := range shouts {
+		if strings.HasPrefix(w, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// armyLine is the HUD line for the army, e.g. "Army 4 - Charge - squad
+// archer" or "Shout: !ho" while a shout is typed. It is empty without
+// units.
+func (g *Game) armyLine() string {
+	if g.shouting {
+		return fmt.Sprintf("Shout: %c%s", shoutKey, g.shoutTyped)
+	}
+	if g.military == nil || g.military.Count() == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("Army %d - %s", g.military.Count(), g.military.Order())
+	if s := g.military.Squad(); s != "" {
+		line += " - squad " + s
+	}
+	return line
+}
@@ -0,0 +1,63 @@
+//go:build test
+
+package game
+
+import "testing"
+
+func TestCommandRallyColumn(t *testing.T) {
+	g := NewGame()
+	g.military.AddUnit(NewFootman(0, 0))
+	g.executeCommand("rally 10")
+	want, _ := g.columnPoint("10")
+	if p, s := g.military.Units()[0].Rally(); p != want || s != StanceRally {
+		t.Errorf("rally 10 should send the army to %v, got %v %v", want, p, s)
+	}
+	g.executeCommand("rally 999")
+	if p, _ := g.military.Units()[0].Rally(); p != want {
+		t.Errorf("an off-grid column should be ignored, got %v", p)
+	}
+}
+
+func TestCommandSquadAndTrain(t *testing.T) {
+	g := NewGame()
+	if g.orderArmy([]string{"squad", "dragons"}) {
+		t.Errorf("unknown squads should be refused")
+	}
+	if !g.orderArmy([]string{"squad", "archers"}) || g.military.Squad() != UnitArcher {
+		t.Errorf("squad archers should select the archer squad, got %q", g.military.Squad())
+	}
+	g.executeCommand("train archer")
+	if g.barracks.Selected() != UnitFootman {
+		t.Errorf("locked units should not be selectable")
+	}
+}
+
+func TestTypingTechLeavesUnitsLocked(t *testing.T) {
+	g := NewGame()
+	for i := 0; i < 7; i++ {
+		g.applyNextTech()
+	}
+	if units := g.barracks.Trainable(); len(units) != 1 {
+		t.Errorf("only Barracks letter stages should unlock units, have %v", units)
+	}
+}
+
+func TestShoutOrdersArmy(t *testing.T) {
+	g := NewGame()
+	g.military.AddUnit(NewFootman(0, 0))
+	rest := g.typeShouts([]rune("f!g"))
+	if string(rest) != "f" {
+		t.Errorf("letters outside the shout should reach the queue, got %q", string(rest))
+	}
+	if !g.shouting {
+		t.Fatalf("shout should still be typed")
+	}
+	g.typeShouts([]rune("o"))
+	if g.shouting || g.military.Order() != StanceCharge {
+		t.Errorf("!go should order a charge, order %v", g.military.Order())
+	}
+	g.typeShouts([]rune("!x"))
+	if g.shouting {
+		t.Errorf("a mistyped shout should be dropped")
+	}
+}
@@ -2,7 +2,8 @@ package game
 
 import "math/rand"
 
-// Barracks represents a Military building that trains Footman units.
+// Barracks represents a Military building that trains units from its roster.
+// Each completed word trains one unit of the selected kind.
 type Barracks struct {
 	timer       CooldownTimer // cooldown timer for word generation
 	letterPool  []rune        // available letters for word generation
@@ -14,6 +15,14 @@ type Barracks struct {
 	active      bool          // is the Barracks running?
 	queue       *QueueManager // optional global queue manager
 	military    *Military     // optional military system to track units
+	resources   *ResourcePool // optional pool that pays for training
+	spawn       Point         // where new units appear
+	bonusHP     int           // extra hit points for trained units
+	bonusDamage int           // extra damage for trained units that deal damage
+	roster      *Roster       // stats of every trainable unit kind
+	unlocked    map[string]bool
+	selected    string // unit kind the next completed word trains
+	supplyCap   int    // supply the army may use, 0 for no cap
 }
 
 // NewBarracks creates a new Barracks with default settings.
@@ -29,12 +38,15 @@ func NewBarracks() *Barracks {
 		active:     true,
 		queue:      nil,
 		military:   nil,
+		roster:     DefaultRoster(),
+		unlocked:   map[string]bool{UnitFootman: true},
+		selected:   UnitFootman,
 	}
 }
 
 // Update ticks the Barracks cooldown and returns a word when ready.
 func (b *Barracks) Update(dt float64) string {
-	if !b.active || b.pendingWord != "" {
+	if !b.active || b.pendingWord != "" || b.SupplyFull() {
 		return ""
 	}
 	if b.timer.Tick(dt) {
@@ -62,12 +74,17 @@ func (b *Barracks) generateWord() string {
 	return b.lastWord
 }
 
-// OnWordCompleted spawns a Footman if the provided word matches the pending one.
-func (b *Barracks) OnWordCompleted(word string) *Footman {
+// OnWordCompleted trains a unit of the selected kind if the provided word
+// matches the pending one and the resource pool can pay for it. Without a
+// pool units train for free.
+func (b *Barracks) OnWordCompleted(word string) Unit {
 	if word == b.pendingWord {
 		b.pendingWord = ""
 		b.timer.Reset()
-		unit := NewFootman(0, 0)
+		if b.resources != nil && !b.resources.Spend(b.TrainingCost()) {
+			return nil
+		}
+		unit := b.train(b.selected, b.spawn)
 		if b.military != nil {
 			b.military.AddUnit(unit)
 		}
@@ -76,6 +93,115 @@ func (b *Barracks) OnWordCompleted(word string) *Footman {
 	return nil
 }
 
+// train creates a unit of the given kind at p with the training bonus
+// applied. Kinds missing from the roster train a Footman.
+func (b *Barracks) train(kind string, p Point) Unit {
+	s := b.roster.Stats(kind)
+	if s == nil {
+		s = b.roster.Stats(UnitFootman)
+	}
+	stats := *s
+	stats.HP += b.bonusHP
+	if stats.Damage > 0 {
+		stats.Damage += b.bonusDamage
+	}
+	return NewUnit(stats, p.X, p.Y)
+}
+
+// SetFootmanBonus sets the extra hit points and damage of trained units.
+func (b *Barracks) SetFootmanBonus(hp, damage int) {
+	b.bonusHP = hp
+	b.bonusDamage = damage
+}
+
+// SetRoster replaces the unit stats table. Units already unlocked stay
+// unlocked if the new roster still has them.
+func (b *Barracks) SetRoster(r *Roster) {
+	if r == nil {
+		return
+	}
+	b.roster = r
+	if r.Stats(b.selected) == nil {
+		b.selected = UnitFootman
+	}
+	b.unlockUnits()
+}
+
+// SetSupplyCap sets the supply the army may use. The Barracks stops
+// offering words while the selected unit would exceed it.
+func (b *Barracks) SetSupplyCap(n int) { b.supplyCap = n }
+
+// SupplyFull reports whether training the selected unit would exceed the
+// supply cap.
+func (b *Barracks) SupplyFull() bool {
+	if b.supplyCap <= 0 || b.military == nil {
+		return false
+	}
+	need := 0
+	if s := b.roster.Stats(b.selected); s != nil {
+		need = s.Supply
+	}
+	return b.military.Supply()+need > b.supplyCap
+}
+
+// TrainingCost returns the price of the selected unit.
+func (b *Barracks) TrainingCost() Cost {
+	if s := b.roster.Stats(b.selected); s != nil {
+		return s.Cost
+	}
+	return Cost{}
+}
+
+// Roster returns the unit stats table.
+func (b *Barracks) Roster() *Roster { return b.roster }
+
+// unlockUnits makes the units of every letter stage reached so far
+// trainable.
+func (b *Barracks) unlockUnits() {
+	for _, u := range b.roster.Units {
+		if u.Stage <= b.unlockStage {
+			b.unlocked[u.ID] = true
+		}
+	}
+}
+
+// NextUnits returns the units the next letter stage unlocks.
+func (b *Barracks) NextUnits() []UnitStats {
+	return b.roster.UnlockedAt(b.unlockStage + 1)
+}
+
+// Unlocked reports whether the unit kind can be trained.
+func (b *Barracks) Unlocked(kind string) bool {
+	return b.unlocked[kind] && b.roster.Stats(kind) != nil
+}
+
+// Select picks the unit kind the next completed word trains. Locked or
+// unknown kinds are refused.
+func (b *Barracks) Select(kind string) bool {
+	if !b.Unlocked(kind) {
+		return false
+	}
+	b.selected = kind
+	return true
+}
+
+// Selected returns the unit kind the next completed word trains.
+func (b *Barracks) Selected() string { return b.selected }
+
+// Trainable returns the ids of the unlocked units in roster order.
+func (b *Barracks) Trainable() []string {
+	var out []string
+	for _, u := range b.roster.Units {
+		if b.unlocked[u.ID] {
+			out = append(out, u.ID)
+		}
+	}
+	return out
+}
+
+// SetSpawnPoint sets where newly trained Footmen appear.
+func (b *Barracks) SetSpawnPoint(p Point) { b.spawn = p }
+
 // SetLetterPool updates the Barracks letter pool.
 func (b *Barracks) SetLetterPool(pool []rune) { b.letterPool = pool }
 
@@ -91,6 +217,9 @@ func (b *Barracks) SetCooldown(c float64) { b.timer.remaining = c }
 // SetQueue assigns a QueueManager for global word management.
 func (b *Barracks) SetQueue(q *QueueManager) { b.queue = q }
 
+// SetResources assigns the pool that pays for training.
+func (b *Barracks) SetResources(pool *ResourcePool) { b.resources = pool }
+
 // SetMilitary assigns a Military system for unit tracking.
 func (b *Barracks) SetMilitary(m *Military) { b.military = m }
 
@@ -117,7 +246,32 @@ func (b *Barracks) UnlockNext(pool *ResourcePool) bool {
 	if pool != nil && pool.SpendKingsPoints(cost) {
 		b.unlockStage = stage
 		b.letterPool = append(b.letterPool, letters...)
+		b.unlockUnits()
 		return true
 	}
 	return false
 }
+
+// Stage returns the letter stage the Barracks has reached.
+func (b *Barracks) Stage() int { return b.unlockStage }
+
+// restore brings a fresh Barracks back to a saved letter stage with the
+// saved units unlocked and selected. Units missing from the roster are
+// skipped.
+func (b *Barracks) restore(stage int, units []string, selected string) {
+	for b.unlockStage < stage {
+		letters := LetterStageLetters(b.unlockStage + 1)
+		if letters == nil {
+			break
+		}
+		b.unlockStage++
+		b.letterPool = append(b.letterPool, letters...)
+	}
+	b.unlockUnits()
+	for _, id := range units {
+		if b.roster.Stats(id) != nil {
+			b.unlocked[id] = true
+		}
+	}
+	b.Select(selected)
+}
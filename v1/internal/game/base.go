@@ -7,6 +7,7 @@ const BaseStartingHealth = 10
 type Base struct {
 	BaseEntity
 	health int
+	peak   int // highest health reached, the cap for healing
 }
 
 // NewBase creates a new base at the given position.
@@ -31,9 +32,20 @@ func (b *Base) Update(dt float64) {}
 
 // Damage reduces the base's health by the given amount.
 func (b *Base) Damage(amount int) {
+	b.peak = max(b.peak, b.health)
 	b.health -= amount
 }
 
+// Heal restores health up to the highest health the base has had and
+// reports whether any was restored.
+func (b *Base) Heal(amount int) bool {
+	if b.health <= 0 || b.health >= b.peak {
+		return false
+	}
+	b.health = min(b.health+amount, b.peak)
+	return true
+}
+
 // Health returns the current health of the base.
 func (b *Base) Health() int {
 	return b.health
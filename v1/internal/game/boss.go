@@ -0,0 +1,385 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// BossesFile is the default path for boss definitions.
+const BossesFile = "bosses.yaml"
+
+// silenceSources are the buildings a boss phase can silence.
+var silenceSources = map[string]bool{"Farmer": true, "Lumberjack": true, "Miner": true, "Barracks": true, "Sanctum": true}
+
+// BossSummon describes adds spawned when a phase begins.
+type BossSummon struct {
+	Mob   string `yaml:"mob"`
+	Count int    `yaml:"count"`
+}
+
+// BossPhase is one stage of a boss fight. A phase begins once the boss's
+// health falls to Threshold of its maximum.
+type BossPhase struct {
+	Name            string      `yaml:"name"`
+	Threshold       float64     `yaml:"threshold"`
+	WeakPoint       string      `yaml:"weak_point"`        // word to type before the boss takes damage again
+	WeakPointDamage float64     `yaml:"weak_point_damage"` // fraction of max health dealt by the weak point
+	Summon          *BossSummon `yaml:"summon"`
+	Shield          int         `yaml:"shield"`  // regenerating shield raised at phase start
+	Silence         string      `yaml:"silence"` // building whose words stop until Counter is typed
+	Counter         string      `yaml:"counter"`
+}
+
+// BossDef is a data-defined boss.
+type BossDef struct {
+	ID     string      `yaml:"id"`
+	Name   string      `yaml:"name"`
+	Phases []BossPhase `yaml:"phases"`
+}
+
+// BossBook holds every boss definition.
+type BossBook struct {
+	Bosses []BossDef `yaml:"bosses"`
+
+	byID map[string]*BossDef
+}
+
+// LoadBosses parses a YAML file into a BossBook and validates it.
+func LoadBosses(path string) (*BossBook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBosses(data)
+}
+
+// ParseBosses parses YAML boss data and validates it.
+func ParseBosses(data []byte) (*BossBook, error) {
+	var b BossBook
+	if err := yaml.UnmarshalStrict(data, &b); err != nil {
+		return nil, err
+	}
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// DefaultBosses returns the built-in boss roster.
+func DefaultBosses() *BossBook {
+	b := &BossBook{Bosses: []BossDef{{
+		ID:   "warlord",
+		Name: "Orc Warlord",
+		Phases: []BossPhase{
+			{Name: "Advance", Threshold: 1},
+			{Name: "Plated", Threshold: 0.66, WeakPoint: "flask", WeakPointDamage: 0.1, Summon: &BossSummon{Mob: "basic", Count: 2}},
+			{Name: "Warcry", Threshold: 0.33, Shield: 5, Silence: "Farmer", Counter: "glad"},
+		},
+	}}}
+	if err := b.validate(); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate checks ids, phase ordering, phrases and effect targets and builds
+// the id lookup.
+func (b *BossBook) validate() error {
+	if len(b.Bosses) == 0 {
+		return fmt.Errorf("no bosses defined")
+	}
+	b.byID = make(map[string]*BossDef, len(b.Bosses))
+	for i := range b.Bosses {
+		d := &b.Bosses[i]
+		if d.ID == "" {
+			return fmt.Errorf("boss %d has no id", i+1)
+		}
+		if _, dup := b.byID[d.ID]; dup {
+			return fmt.Errorf("duplicate boss %s", d.ID)
+		}
+		if len(d.Phases) == 0 || d.Phases[0].Threshold != 1 {
+			return fmt.Errorf("boss %s must start with a phase at threshold 1", d.ID)
+		}
+		for j, p := range d.Phases {
+			where := fmt.Sprintf("boss %s phase %d", d.ID, j+1)
+			if p.Threshold <= 0 || p.Threshold > 1 || (j > 0 && p.Threshold >= d.Phases[j-1].Threshold) {
+				return fmt.Errorf("%s: thresholds must fall from 1 towards 0", where)
+			}
+			if !lowercaseWord(p.WeakPoint) || !lowercaseWord(p.Counter) {
+				return fmt.Errorf("%s: phrases must be lowercase letters", where)
+			}
+			if p.WeakPointDamage < 0 || p.WeakPointDamage > 1 || p.Shield < 0 {
+				return fmt.Errorf("%s: value out of range", where)
+			}
+			if p.Summon != nil {
+				if k, ok := mobKinds[p.Summon.Mob]; !ok || k == MobBoss {
+					return fmt.Errorf("%s: cannot summon %q", where, p.Summon.Mob)
+				}
+				if p.Summon.Count < 1 {
+					return fmt.Errorf("%s: summon count must be at least 1", where)
+				}
+			}
+			if p.Silence != "" && (!silenceSources[p.Silence] || p.Counter == "") {
+				return fmt.Errorf("%s: silence needs a known building and a counter phrase", where)
+			}
+		}
+		b.byID[d.ID] = d
+	}
+	return nil
+}
+
+// lowercaseWord reports whether s is empty or only lowercase ASCII letters.
+func lowercaseWord(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Boss returns the definition with the given id, or the first boss when id
+// is empty.
+func (b *BossBook) Boss(id string) (*BossDef, bool) {
+	if b == nil || len(b.Bosses) == 0 {
+		return nil, false
+	}
+	if id == "" {
+		return &b.Bosses[0], true
+	}
+	d, ok := b.byID[id]
+	return d, ok
+}
+
+// CheckBosses reports wave groups that name a boss missing from the book.
+func (s *WaveScript) CheckBosses(b *BossBook) error {
+	for i, w := range s.Waves {
+		for j, g := range w.Groups {
+			if g.Mob != "boss" {
+				continue
+			}
+			if _, ok := b.Boss(g.Boss); !ok {
+				return fmt.Errorf("wave %d group %d: unknown boss %q", i+1, j+1, g.Boss)
+			}
+		}
+	}
+	return nil
+}
+
+// SetBosses replaces the boss definitions used by later boss spawns.
+func (g *Game) SetBosses(b *BossBook) {
+	if b != nil {
+		g.bosses = b
+	}
+}
+
+// bossPhrase tracks progress typing a weak point or counter phrase.
+type bossPhrase struct {
+	text  string
+	typed int
+}
+
+// active reports whether the phrase still needs typing.
+func (p *bossPhrase) active() bool { return p.typed < len(p.text) }
+
+// typeLetter advances the phrase and returns true when it is completed. A
+// wrong letter restarts the phrase, or counts as its first letter if it
+// matches.
+func (p *bossPhrase) typeLetter(r rune) bool {
+	if !p.active() {
+		return false
+	}
+	switch {
+	case rune(p.text[p.typed]) == r:
+		p.typed++
+	case rune(p.text[0]) == r:
+		p.typed = 1
+	default:
+		p.typed = 0
+	}
+	return !p.active()
+}
+
+// progress returns the letters typed into a phrase still being typed.
+func (p *bossPhrase) progress() int {
+	if !p.active() {
+		return 0
+	}
+	return p.typed
+}
+
+// Boss is a mob that moves through scripted phases as it loses health.
+type Boss struct {
+	*Mob
+	def     *BossDef
+	game    *Game
+	maxHP   int
+	phase   int
+	weak    bossPhrase
+	counter bossPhrase
+	silence string
+}
+
+// NewBoss creates a boss from its definition and enters the first phase.
+func NewBoss(g *Game, def *BossDef, x, y float64, hp int, speed float64) *Boss {
+	b := &Boss{Mob: NewBossMob(x, y, g.base, hp, speed), def: def, game: g, maxHP: hp, phase: -1}
+	b.advancePhases()
+	return b
+}
+
+// Damage hurts the boss unless a weak point is exposed, then starts any
+// phases whose threshold was crossed.
+func (b *Boss) Damage(d int) {
+	if b.weak.active() {
+		return
+	}
+	b.Mob.Damage(d)
+	b.advancePhases()
+}
+
+// advancePhases enters every phase whose health threshold has been reached.
+func (b *Boss) advancePhases() {
+	for b.alive && b.phase+1 < len(b.def.Phases) {
+		next := b.def.Phases[b.phase+1]
+		if float64(b.health) > next.Threshold*float64(b.maxHP) {
+			return
+		}
+		b.phase++
+		b.enterPhase(next)
+	}
+}
+
+// enterPhase applies the effects of a newly started phase.
+func (b *Boss) enterPhase(p BossPhase) {
+	b.weak = bossPhrase{text: p.WeakPoint}
+	if p.Counter != "" {
+		b.counter = bossPhrase{text: p.Counter}
+		b.silence = p.Silence
+	}
+	if p.Shield > 0 {
+		b.shield, b.maxShield = p.Shield, p.Shield
+		b.shieldBroken = false
+		b.shieldTimer = NewCooldownTimer(shieldWindow)
+		b.shieldTimer.remaining = 0
+	}
+	if p.Summon != nil && b.game != nil {
+		b.game.summonAdds(b.Mob, mobKinds[p.Summon.Mob], p.Summon.Count)
+	}
+}
+
+// typeLetter feeds a typed letter to the boss's active phrases.
+func (b *Boss) typeLetter(r rune) (progress int, done bool) {
+	if !b.alive {
+		return 0, false
+	}
+	if b.weak.typeLetter(r) {
+		done = true
+		phase := b.def.Phases[b.phase]
+		b.health -= int(math.Ceil(phase.WeakPointDamage * float64(b.maxHP)))
+		if b.health <= 0 {
+			b.alive = false
+		}
+		b.advancePhases()
+	}
+	if b.counter.typeLetter(r) {
+		done = true
+		b.silence = ""
+	}
+	return max(b.weak.progress(), b.counter.progress()), done
+}
+
+// restartPhrases drops the progress of the boss's phrases.
+func (b *Boss) restartPhrases() {
+	if b.weak.active() {
+		b.weak.typed = 0
+	}
+	if b.counter.active() {
+		b.counter.typed = 0
+	}
+}
+
+// Phase returns the current phase index and definition.
+func (b *Boss) Phase() (int, BossPhase) { return b.phase, b.def.Phases[b.phase] }
+
+// HealthFraction returns remaining health as a fraction of the maximum.
+func (b *Boss) HealthFraction() float64 {
+	if b.maxHP <= 0 {
+		return 0
+	}
+	return math.Max(0, float64(b.health)/float64(b.maxHP))
+}
+
+// summonAdds spawns count mobs of the given type around the summoner.
+func (g *Game) summonAdds(from *Mob, kind MobType, count int) {
+	for i := 0; i < count; i++ {
+		y := from.pos.Y + float64((i%3)-1)*float64(TileSize)
+		var m *Mob
+		if kind == MobGrunt {
+			g.spawnGrunt(from.pos.X+float64(TileSize), y, gruntBaseHP, nil)
+			continue
+		} else if kind == MobWord {
+			m = NewWordMob(from.pos.X+float64(TileSize), y, g.base, g.mobWord(), from.speed*2)
+		} else {
+			m = newMobOfType(kind, from.pos.X+float64(TileSize), y, g.base, from.health/10+1, from.speed*2)
+		}
+		g.routeMob(m)
+		g.mobs = append(g.mobs, m)
+	}
+}
+
+// activeBoss returns the first boss still alive on the field.
+func (g *Game) activeBoss() *Boss {
+	for _, e := range g.mobs {
+		if b, ok := e.(*Boss); ok && b.Alive() {
+			return b
+		}
+	}
+	return nil
+}
+
+// silenced reports whether a boss phase has silenced the building or siege
+// has wrecked it.
+func (g *Game) silenced(source string) bool {
+	if g.wrecked(source) {
+		return true
+	}
+	for _, e := range g.mobs {
+		if b, ok := e.(*Boss); ok && b.Alive() && b.silence == source {
+			return true
+		}
+	}
+	return false
+}
+
+// typeBossPhrases feeds typed letters to every living boss and returns the
+// letters left for the queue. Letters are held while they spell the start of
+// a phrase: completing it consumes them, breaking it hands them on.
+func (g *Game) typeBossPhrases(typed []rune) []rune {
+	var rest []rune
+	for _, r := range typed {
+		g.bossHeld = append(g.bossHeld, r)
+		keep, done := 0, false
+		for _, e := range g.mobs {
+			if b, ok := e.(*Boss); ok {
+				n, d := b.typeLetter(r)
+				keep, done = max(keep, n), done || d
+			}
+		}
+		if done {
+			for _, e := range g.mobs {
+				if b, ok := e.(*Boss); ok {
+					b.restartPhrases()
+				}
+			}
+			g.bossHeld = g.bossHeld[:0]
+			continue
+		}
+		n := len(g.bossHeld) - keep
+		rest = append(rest, g.bossHeld[:n]...)
+		g.bossHeld = append(g.bossHeld[:0], g.bossHeld[n:]...)
+	}
+	return rest
+}
@@ -0,0 +1,147 @@
+package game
+
+import "testing"
+
+func typeBoss(g *Game, s string) []rune {
+	return g.typeBossPhrases([]rune(s))
+}
+
+func TestBossPhasesFollowThresholds(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, bosses: DefaultBosses()}
+	def, _ := g.bosses.Boss("warlord")
+	b := NewBoss(g, def, 1500, 500, 100, 1)
+	g.mobs = []Enemy{b}
+	if idx, _ := b.Phase(); idx != 0 {
+		t.Fatalf("boss should start in phase 1, got %d", idx+1)
+	}
+	b.Damage(40)
+	if idx, p := b.Phase(); idx != 1 || p.Name != "Plated" {
+		t.Fatalf("expected Plated phase, got %d %s", idx+1, p.Name)
+	}
+	if len(g.mobs) != 3 {
+		t.Errorf("phase should summon 2 adds, have %d mobs", len(g.mobs))
+	}
+}
+
+func TestBossWeakPointBlocksDamage(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, bosses: DefaultBosses()}
+	def, _ := g.bosses.Boss("warlord")
+	b := NewBoss(g, def, 1500, 500, 100, 1)
+	g.mobs = []Enemy{b}
+	b.Damage(40)
+	b.Damage(10)
+	if b.health != 60 {
+		t.Fatalf("boss should ignore damage while the weak point is exposed, hp %d", b.health)
+	}
+	typeBoss(g, "flask")
+	if b.health != 50 {
+		t.Fatalf("typing the weak point should deal 10%% of max hp, hp %d", b.health)
+	}
+	b.Damage(10)
+	if b.health != 40 {
+		t.Errorf("boss should take damage once the weak point is typed, hp %d", b.health)
+	}
+}
+
+func TestBossPhraseNeedsUnbrokenTyping(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, bosses: DefaultBosses()}
+	def, _ := g.bosses.Boss("warlord")
+	b := NewBoss(g, def, 1500, 500, 100, 1)
+	g.mobs = []Enemy{b}
+	b.Damage(40)
+	if rest := g.typeBossPhrases([]rune("flxaxsxk")); string(rest) != "flxaxsxk" {
+		t.Errorf("broken phrase letters should go on to the queue, got %q", string(rest))
+	}
+	if b.health != 60 || !b.weak.active() {
+		t.Fatalf("interleaved wrong letters should not complete the weak point, hp %d", b.health)
+	}
+	if rest := typeBoss(g, "fflask"); string(rest) != "f" || b.health != 50 {
+		t.Errorf("a restarted phrase should complete and keep its letters, left %q hp %d", string(rest), b.health)
+	}
+}
+
+func TestBossSilenceAndCounter(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, bosses: DefaultBosses()}
+	def, _ := g.bosses.Boss("warlord")
+	b := NewBoss(g, def, 1500, 500, 100, 1)
+	g.mobs = []Enemy{b}
+	b.Damage(40)
+	typeBoss(g, "flask")
+	b.Damage(20)
+	if idx, _ := b.Phase(); idx != 2 {
+		t.Fatalf("expected final phase, got %d", idx+1)
+	}
+	if b.Shield() != 5 {
+		t.Errorf("final phase should raise a shield, got %d", b.Shield())
+	}
+	if !g.silenced("Farmer") || g.silenced("Miner") {
+		t.Fatalf("only the Farmer should be silenced")
+	}
+	typeBoss(g, "glad")
+	if g.silenced("Farmer") {
+		t.Errorf("counter phrase should lift the silence")
+	}
+}
+
+func TestBossDeathLiftsSilence(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, bosses: DefaultBosses()}
+	def, _ := g.bosses.Boss("warlord")
+	b := NewBoss(g, def, 1500, 500, 100, 1)
+	g.mobs = []Enemy{b}
+	b.Damage(40)
+	typeBoss(g, "flask")
+	b.Damage(20)
+	b.kill()
+	if g.silenced("Farmer") {
+		t.Errorf("a dead boss should not silence buildings")
+	}
+}
+
+func TestSpawnBossFromWaveGroup(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, bosses: DefaultBosses(), currentWave: 1}
+	g.spawnMob(waveSpawn{group: WaveGroup{Mob: "boss", Count: 1, Boss: "warlord"}, hpScale: 1})
+	if g.activeBoss() == nil {
+		t.Fatal("boss group should spawn a scripted boss")
+	}
+}
+
+func TestBossValidation(t *testing.T) {
+	cases := map[string]string{
+		"no bosses":       "bosses: []",
+		"first threshold": "bosses:\n  - id: a\n    phases:\n      - {name: x, threshold: 0.5}\n",
+		"rising":          "bosses:\n  - id: a\n    phases:\n      - {threshold: 1}\n      - {threshold: 0.5}\n      - {threshold: 0.6}\n",
+		"duplicate":       "bosses:\n  - id: a\n    phases:\n      - {threshold: 1}\n  - id: a\n    phases:\n      - {threshold: 1}\n",
+		"bad phrase":      "bosses:\n  - id: a\n    phases:\n      - {threshold: 1, weak_point: Two Words}\n",
+		"bad summon":      "bosses:\n  - id: a\n    phases:\n      - {threshold: 1, summon: {mob: boss, count: 1}}\n",
+		"no counter":      "bosses:\n  - id: a\n    phases:\n      - {threshold: 1, silence: Farmer}\n",
+		"bad building":    "bosses:\n  - id: a\n    phases:\n      - {threshold: 1, silence: Tower, counter: abc}\n",
+	}
+	for name, data := range cases {
+		if _, err := ParseBosses([]byte(data)); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestCheckBosses(t *testing.T) {
+	s, err := ParseWaveScript([]byte("waves:\n  - groups:\n      - {mob: boss, count: 1, boss: dragon}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.CheckBosses(DefaultBosses()) == nil {
+		t.Errorf("unknown boss should be reported")
+	}
+	if err := DefaultWaveScript(DefaultConfig).CheckBosses(DefaultBosses()); err != nil {
+		t.Errorf("default waves reference unknown boss: %v", err)
+	}
+}
+
+func TestBossesFileParses(t *testing.T) {
+	b, err := LoadBosses("../../" + BossesFile)
+	if err != nil {
+		t.Fatalf("load %s: %v", BossesFile, err)
+	}
+	if len(b.Bosses) != len(DefaultBosses().Bosses) {
+		t.Errorf("%s should mirror DefaultBosses", BossesFile)
+	}
+}
@@ -7,6 +7,8 @@ func TestBuildTowerCostsGold(t *testing.T) {
 	cfg.TowerConstructionCost = 5
 	g := NewGameWithConfig(cfg)
 	g.AddGold(10)
+	g.resources.AddWood(2)
+	g.resources.AddStone(2)
 	g.cursorX = 4
 	g.cursorY = 4
 	initial := len(g.towers)
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	buildingHP           = 8 // hit points of a production building
+	buildingSize         = 20
+	buildingRepairSource = "Building"
+)
+
+// buildingSpots lists the production buildings with their row offset from
+// the base. They stand in the first grid column.
+var buildingSpots = []struct {
+	name string
+	row  int
+}{{"Farmer", -2}, {"Lumberjack", -1}, {"Miner", 1}, {"Barracks", 2}, {"Sanctum", 3}}
+
+// Building is a production building beside the base. Siege enemies can wreck
+// it, which stops its words until its repair word is typed.
+type Building struct {
+	name       string // Farmer, Lumberjack, Miner, Barracks or Sanctum
+	pos        Point
+	hp         int
+	maxHP      int
+	repairWord string // queued once the building is damaged
+}
+
+// newBuildings places the production buildings beside the base.
+func newBuildings(base *Base) []*Building {
+	row := (int(base.pos.Y) - TopMargin) / TileSize
+	var out []*Building
+	for _, s := range buildingSpots {
+		out = append(out, &Building{name: s.name, pos: tileCenter([2]int{0, row + s.row}), hp: buildingHP, maxHP: buildingHP})
+	}
+	return out
+}
+
+// Position returns the building's centre.
+func (b *Building) Position() (x, y float64) { return b.pos.X, b.pos.Y }
+
+// Alive reports whether the building still stands. Wrecked buildings are not
+// targeted again.
+func (b *Building) Alive() bool { return b.hp > 0 }
+
+// Damage reduces the building's health, stopping at zero.
+func (b *Building) Damage(amount int) {
+	b.hp = max(0, b.hp-amount)
+}
+
+// Draw renders the building, greyed out while wrecked, with a health bar
+// once damaged.
+func (b *Building) Draw(screen *ebiten.Image) {
+	x := float32(b.pos.X) - buildingSize/2
+	y := float32(b.pos.Y) - buildingSize/2
+	fill := color.RGBA{150, 110, 60, 255}
+	if !b.Alive() {
+		fill = color.RGBA{90, 90, 90, 255}
+	}
+	vector.DrawFilledRect(screen, x, y, buildingSize, buildingSize, fill, false)
+	if b.hp >= b.maxHP {
+		return
+	}
+	vector.DrawFilledRect(screen, x, y-6, buildingSize, 4, color.RGBA{80, 0, 0, 255}, false)
+	vector.DrawFilledRect(screen, x, y-6, buildingSize*float32(b.hp)/float32(b.maxHP), 4, color.RGBA{0, 200, 0, 255}, false)
+}
+
+// wrecked reports whether siege has brought the named building down.
+func (g *Game) wrecked(name string) bool {
+	for _, b := range g.buildings {
+		if b.name == name && !b.Alive() {
+			return true
+		}
+	}
+	return false
+}
+
+// damageBuilding hurts a building and queues its repair word on the first
+// hit.
+func (g *Game) damageBuilding(b *Building, damage int) {
+	b.Damage(damage)
+	if b.repairWord == "" && g.queue != nil {
+		b.repairWord = g.newRepairWord()
+		g.queue.Enqueue(Word{Text: b.repairWord, Source: buildingRepairSource, Family: repairWordFamily})
+	}
+}
+
+// repairBuilding restores the building waiting on the completed repair word
+// to full health.
+func (g *Game) repairBuilding(word string) {
+	for _, b := range g.buildings {
+		if b.repairWord == word {
+			b.hp = b.maxHP
+			b.repairWord = ""
+			return
+		}
+	}
+}
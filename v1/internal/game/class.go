@@ -0,0 +1,184 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+// Kinds of class ability.
+const (
+	abilityWarhorn = "warhorn" // deploys Footmen at the base
+	abilityVolley  = "volley"  // damages every enemy on the field
+)
+
+// ClassAbility is an active ability cast by typing its phrase.
+type ClassAbility struct {
+	Name     string
+	Phrase   string  // lowercase phrase that casts the ability
+	Kind     string  // abilityWarhorn or abilityVolley
+	Power    int     // Footmen deployed or damage dealt
+	Cooldown float64 // seconds before the ability can be cast again
+}
+
+// ClassDef describes a playable class: its passive bonuses, starting kit and
+// active ability. Its skill tree branch is the set of nodes with a matching
+// Class.
+type ClassDef struct {
+	Name          string
+	BaseHP        int               // extra base hit points
+	FootmanHP     int               // extra hit points for trained units
+	FootmanDamage int               // extra damage for trained units
+	TowerDiscount map[TowerType]int // percent off the construction cost
+	Tower         StatModifier      // passive bonus for every tower
+	KitTower      TowerType         // type of the starting tower
+	KitFootmen    int               // Footmen deployed at the start
+	Ability       ClassAbility
+}
+
+// classes holds the classes offered in PreGame. The Knight leads a sturdier
+// army from a tougher base; the Archer builds cheaper Snipers that see
+// further ahead.
+var classes = map[string]*ClassDef{
+	"Knight": {
+		Name:          "Knight",
+		BaseHP:        5,
+		FootmanHP:     5,
+		FootmanDamage: 1,
+		KitFootmen:    2,
+		Ability:       ClassAbility{Name: "Warhorn", Phrase: "warhorn", Kind: abilityWarhorn, Power: 3, Cooldown: 60},
+	},
+	"Archer": {
+		Name:          "Archer",
+		TowerDiscount: map[TowerType]int{TowerSniper: 25},
+		Tower:         StatModifier{ForesightAdd: 2},
+		KitTower:      TowerSniper,
+		Ability:       ClassAbility{Name: "Volley", Phrase: "volley", Kind: abilityVolley, Power: 3, Cooldown: 45},
+	},
+}
+
+// Class returns the name of the run's class, or "" when none was chosen.
+func (g *Game) Class() string {
+	if g.class == nil {
+		return ""
+	}
+	return g.class.Name
+}
+
+// SetClass selects the named class and applies its passive bonuses. Unknown
+// names are ignored.
+func (g *Game) SetClass(name string) bool {
+	c, ok := classes[name]
+	if !ok {
+		return false
+	}
+	g.class = c
+	g.abilityTimer = NewCooldownTimer(c.Ability.Cooldown)
+	g.abilityTimer.remaining = 0 // ready from the start
+	g.abilityPhrase = newPhraseSet(c.Ability.Phrase)
+	if g.skillTree != nil {
+		g.skillTree.SetClass(c.Name)
+	}
+	if g.barracks != nil {
+		g.barracks.SetFootmanBonus(c.FootmanHP, c.FootmanDamage)
+	}
+	if def, ok := heroes[c.Name]; ok {
+		g.hero = NewHero(def)
+	}
+	g.refreshTowerStats()
+	return true
+}
+
+// applyClassKit gives a fresh run the class's extra base health, starting
+// tower and Footmen.
+func (g *Game) applyClassKit() {
+	c := g.class
+	if c == nil {
+		return
+	}
+	if g.base != nil {
+		g.base.health += c.BaseHP
+	}
+	if c.KitTower != TowerBasic && len(g.towers) > 0 {
+		old := g.towers[0]
+		g.towers[0] = NewTowerWithType(g, old.pos.X, old.pos.Y, c.KitTower)
+		g.applySynergies()
+	}
+	for i := 0; i < c.KitFootmen; i++ {
+		g.deployFootman()
+	}
+}
+
+// deployFootman adds a Footman with the Barracks training bonus at the base.
+func (g *Game) deployFootman() {
+	if g.military == nil || g.barracks == nil || g.base == nil {
+		return
+	}
+	g.military.AddUnit(g.barracks.train(UnitFootman, g.base.pos))
+}
+
+// towerCost returns the construction cost of a tower type after the class
+// discount, which applies to every resource.
+func (g *Game) towerCost(tt TowerType) Cost {
+	cost := Cost{Gold: g.towerConstructionCost()}.Plus(g.costs().Tower)
+	if g.class != nil {
+		if pct := g.class.TowerDiscount[tt]; pct > 0 {
+			cost = cost.Minus(cost.Percent(pct))
+		}
+	}
+	return cost
+}
+
+// classStatModifier returns the class's passive tower bonus.
+func (g *Game) classStatModifier() StatModifier {
+	if g.class == nil {
+		return StatModifier{}
+	}
+	return g.class.Tower
+}
+
+// typeClassAbility feeds typed letters to the class ability phrase and casts
+// the ability once the whole phrase is typed while it is off cooldown. It
+// returns the letters left for the queue.
+func (g *Game) typeClassAbility(typed []rune) []rune {
+	if g.class == nil {
+		return typed
+	}
+	ready := func(int) bool { return g.abilityTimer.Ready() }
+	return g.abilityPhrase.take(typed, ready, func(int) { g.castClassAbility() })
+}
+
+// castClassAbility triggers the class ability and starts its cooldown.
+func (g *Game) castClassAbility() {
+	a := g.class.Ability
+	switch a.Kind {
+	case abilityWarhorn:
+		for i := 0; i < a.Power; i++ {
+			g.deployFootman()
+		}
+	case abilityVolley:
+		for _, m := range g.mobs {
+			if m.Alive() {
+				m.Damage(a.Power)
+			}
+		}
+	}
+	g.abilityTimer.Reset()
+}
+
+// scaleAbilityCooldown shortens the class ability cooldown by mult.
+func (g *Game) scaleAbilityCooldown(mult float64) {
+	g.abilityTimer.SetInterval(g.abilityTimer.interval * mult)
+}
+
+// classLine is the HUD line for the class ability, e.g. "Knight: type
+// warhorn" or "Archer: Volley in 12s".
+func (g *Game) classLine() string {
+	if g.class == nil {
+		return ""
+	}
+	a := g.class.Ability
+	if g.abilityTimer.Ready() {
+		return fmt.Sprintf("%s: type %s (%s)", g.class.Name, a.Phrase, a.Name)
+	}
+	return fmt.Sprintf("%s: %s in %.0fs", g.class.Name, a.Name, math.Ceil(g.abilityTimer.Remaining()))
+}
@@ -0,0 +1,149 @@
+//go:build test
+
+package game
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPreGameClassesExist(t *testing.T) {
+	for _, name := range NewPreGame().charOptions {
+		if _, ok := classes[name]; !ok {
+			t.Errorf("PreGame offers %s but no class is defined", name)
+		}
+	}
+}
+
+func TestKnightKitAndPassives(t *testing.T) {
+	plain := NewGame()
+	g := NewGame()
+	g.SetClass("Knight")
+	g.startRun()
+	if g.base.Health() != plain.base.Health()+5 {
+		t.Errorf("Knight base HP %d, want %d", g.base.Health(), plain.base.Health()+5)
+	}
+	if g.military.Count() != 2 {
+		t.Errorf("Knight should start with 2 Footmen, have %d", g.military.Count())
+	}
+	g.barracks.pendingWord = "fjfj"
+	f, _ := g.barracks.OnWordCompleted("fjfj").(*Footman)
+	if f == nil || f.hp != 15 || f.damage != 2 {
+		t.Errorf("Knight Footmen should have 15 HP and 2 damage, got %+v", f)
+	}
+}
+
+func TestArcherKitAndPassives(t *testing.T) {
+	g := NewGame()
+	g.SetClass("Archer")
+	g.startRun()
+	if g.towers[0].towerType != TowerSniper {
+		t.Errorf("Archer should start with a Sniper tower")
+	}
+	if g.towerCost(TowerSniper).Gold != 15 || g.towerCost(TowerBasic).Gold != 20 {
+		t.Errorf("only Snipers should be discounted, got %v and %v", g.towerCost(TowerSniper), g.towerCost(TowerBasic))
+	}
+	if g.towers[0].foresight != baseForesight+2 {
+		t.Errorf("Archer towers should preview 2 extra letters, got %d", g.towers[0].foresight)
+	}
+}
+
+func TestClassAbilityPhrase(t *testing.T) {
+	g := NewGame()
+	g.SetClass("Knight")
+	g.startRun()
+	before := g.military.Count()
+	if rest := g.typeClassAbility([]rune("xwarwarhorn")); string(rest) != "xwar" {
+		t.Errorf("the ability should keep its letters from the queue, left %q", string(rest))
+	}
+	if g.military.Count() != before+3 {
+		t.Fatalf("warhorn should deploy 3 Footmen, have %d", g.military.Count()-before)
+	}
+	g.typeClassAbility([]rune("warhorn"))
+	if g.military.Count() != before+3 {
+		t.Errorf("ability should be on cooldown")
+	}
+
+	a := NewGame()
+	a.SetClass("Archer")
+	a.startRun()
+	m := NewMob(500, 500, a.base, 5, 1)
+	a.mobs = []Enemy{m}
+	a.typeClassAbility([]rune("volley"))
+	if m.health != 2 {
+		t.Errorf("volley should deal 3 damage, hp %d", m.health)
+	}
+}
+
+func TestClassSkillBranch(t *testing.T) {
+	g := NewGame()
+	g.SetClass("Archer")
+	g.startRun()
+	g.resources.AddKingsPoints(100)
+	if g.skillTree.CanUnlock("shield_wall", &g.resources) {
+		t.Errorf("an Archer should not unlock Knight skills")
+	}
+	if !g.skillTree.CanUnlock("eagle_eye", &g.resources) {
+		t.Errorf("an Archer should unlock Archer skills")
+	}
+	for _, n := range g.skillNodesByCategory(SkillClass) {
+		if n.Class != "Archer" {
+			t.Errorf("class branch should only list Archer nodes, got %s", n.ID)
+		}
+	}
+}
+
+func TestSaveKeepsClass(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.json")
+	g := NewGame()
+	g.SetClass("Knight")
+	g.startRun()
+	g.saveGame(path)
+	l := NewGame()
+	if err := l.loadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if l.Class() != "Knight" {
+		t.Errorf("loaded class %q, want Knight", l.Class())
+	}
+}
+
+// playPreGame confirms every PreGame screen with the default choices.
+func playPreGame(t *testing.T, g *Game) {
+	t.Helper()
+	g.phase = PhasePreGame
+	g.preGame = NewPreGame()
+	inp := &pgInput{}
+	g.input = inp
+	for _, chars := range [][]rune{nil, nil, nil, []rune("ready"), nil} {
+		inp.enter, inp.chars = chars == nil, chars
+		g.lastUpdate = time.Now()
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.phase != PhasePlaying {
+		t.Fatalf("PreGame should start the run, phase %v", g.phase)
+	}
+}
+
+func TestRestartAppliesClassKitOnce(t *testing.T) {
+	g := NewGame()
+	playPreGame(t, g)
+	hp, footmen := g.base.Health(), g.military.Count()
+	if footmen != 2 {
+		t.Fatalf("Knight should start with 2 Footmen, have %d", footmen)
+	}
+	g.Restart()
+	if g.barracks.resources != &g.resources {
+		t.Fatalf("the Barracks should pay from the restarted game's pool")
+	}
+	if g.military.Count() != 0 {
+		t.Errorf("the kit should wait for PreGame, have %d Footmen", g.military.Count())
+	}
+	playPreGame(t, g)
+	if g.base.Health() != hp || g.military.Count() != footmen {
+		t.Errorf("restarted run got %d HP and %d Footmen, want %d and %d", g.base.Health(), g.military.Count(), hp, footmen)
+	}
+}
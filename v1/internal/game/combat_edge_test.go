@@ -26,7 +26,7 @@ func TestFootmanSurvivesAfterKill(t *testing.T) {
 	m.AddUnit(f)
 	orcs := []*OrcGrunt{o}
 
-	for steps := 0; o.Alive() && steps < 10; steps++ {
+	for steps := 0; o.Alive() && steps < 25; steps++ {
 		orcs = updateOrcs(orcs, 0.1)
 		orcs = m.Update(0.1, orcs)
 	}
@@ -75,7 +75,7 @@ func TestCombatMultipleCombinations(t *testing.T) {
 			orcs = append(orcs, o)
 		}
 
-		for steps := 0; steps < 20 && m.Count() > 0 && len(orcs) > 0; steps++ {
+		for steps := 0; steps < 40 && m.Count() > 0 && len(orcs) > 0; steps++ {
 			orcs = updateOrcs(orcs, 0.1)
 			orcs = m.Update(0.1, orcs)
 		}
@@ -148,7 +148,7 @@ func TestImmediateRemovalOfDeadUnits(t *testing.T) {
 	m.AddUnit(f)
 	orcs := []*OrcGrunt{o}
 
-	for steps := 0; steps < 10 && len(orcs) > 0; steps++ {
+	for steps := 0; steps < 25 && len(orcs) > 0; steps++ {
 		orcs = updateOrcs(orcs, 0.1)
 		orcs = m.Update(0.1, orcs)
 	}
@@ -254,3 +254,26 @@ func TestRemovalDuringIterationNoPanic(t *testing.T) {
 		orcs = m.Update(0.1, orcs)
 	}
 }
+
+func TestMeleeWaitsForSwing(t *testing.T) {
+	f := NewFootman(0, 0)
+	f.speed = 0
+	o := NewOrcGrunt(0, 0)
+	o.speed = 0
+	o.hp = 100
+
+	m := NewMilitary()
+	m.AddUnit(f)
+	orcs := []*OrcGrunt{o}
+
+	for steps := 0; steps < 10; steps++ {
+		orcs = m.Update(0.01, orcs)
+	}
+	if o.Health() != 99 || f.Health() != 9 {
+		t.Fatalf("one swing should trade a single blow, grunt hp %d footman hp %d", o.Health(), f.Health())
+	}
+	orcs = m.Update(meleeInterval, orcs)
+	if o.Health() != 98 || f.Health() != 8 {
+		t.Errorf("the next blow should land once the swing is ready, grunt hp %d footman hp %d", o.Health(), f.Health())
+	}
+}
@@ -47,6 +47,8 @@ type Config struct {
 	TowerBounce       int     `json:"tower_bounce_count"`
 
 	TowerConstructionCost int `json:"tower_construction_cost"`
+	TowerRefundPercent    int `json:"tower_refund_percent"` // share of resources returned when selling
+	TowerMoveCost         int `json:"tower_move_cost"`      // gold paid when a relocation word is typed
 
 	ProjectileSpeed float64 `json:"projectile_speed"`
 
@@ -57,6 +59,11 @@ type Config struct {
 	MobsPerWave    int     `json:"mobs_per_wave_base"`
 	MobsPerWaveInc int     `json:"mobs_per_wave_growth"`
 	SpawnInterval  float64 `json:"spawn_interval"` // milliseconds between spawns
+
+	Costs       Costs      `json:"costs"`        // resource prices of purchases
+	KingsPoints KingsRules `json:"kings_points"` // how King's Points are earned
+
+	Difficulties []DifficultyProfile `json:"difficulties"` // selectable in PreGame
 }
 
 // DefaultConfig provides baseline parameters used when a new game starts.
@@ -97,6 +104,8 @@ var DefaultConfig = Config{
 	TowerBounce:       0,
 
 	TowerConstructionCost: 20,
+	TowerRefundPercent:    50,
+	TowerMoveCost:         5,
 
 	ProjectileSpeed: 5.0,
 
@@ -107,6 +116,11 @@ var DefaultConfig = Config{
 	MobsPerWave:    3,
 	MobsPerWaveInc: 3,
 	SpawnInterval:  1000, // ms
+
+	Costs:       DefaultCosts,
+	KingsPoints: DefaultKingsRules,
+
+	Difficulties: DefaultDifficulties,
 }
 
 // LoadConfig reads configuration values from the given JSON file.
@@ -117,9 +131,32 @@ func LoadConfig(path string) (Config, error) {
 		return DefaultConfig, err
 	}
 	cfg := DefaultConfig
+	cfg.Difficulties = nil // decoding must not write into the default slice
+	cfg.KingsPoints.Accuracy, cfg.KingsPoints.WPM = nil, nil
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return DefaultConfig, err
 	}
+	if len(cfg.Difficulties) == 0 {
+		cfg.Difficulties = DefaultConfig.Difficulties
+	}
+	if err := validateDifficulties(cfg.Difficulties); err != nil {
+		return DefaultConfig, err
+	}
+	if cfg.KingsPoints.Accuracy == nil {
+		cfg.KingsPoints.Accuracy = DefaultKingsRules.Accuracy
+	}
+	if cfg.KingsPoints.WPM == nil {
+		cfg.KingsPoints.WPM = DefaultKingsRules.WPM
+	}
+	if err := validateKingsRules(cfg.KingsPoints); err != nil {
+		return DefaultConfig, err
+	}
+	if err := validateCosts(cfg.Costs); err != nil {
+		return DefaultConfig, err
+	}
+	if err := validateTowerManagement(cfg); err != nil {
+		return DefaultConfig, err
+	}
 	// Convert ms to seconds for all time-based fields
 	cfg.TowerFireRate = cfg.TowerFireRate / 1000.0
 	cfg.SpawnInterval = cfg.SpawnInterval / 1000.0
@@ -22,6 +22,10 @@ var (
 	ImgMobA                 *ebiten.Image
 	ImgMobB                 *ebiten.Image
 	ImgFootman              *ebiten.Image
+	ImgArcherUnit           *ebiten.Image
+	ImgKnight               *ebiten.Image
+	ImgHealer               *ebiten.Image
+	ImgHero                 *ebiten.Image
 	ImgProjectile           *ebiten.Image
 )
 
@@ -35,6 +39,10 @@ func InitImages() {
 	ImgMobA = generateMobImage(color.RGBA{255, 0, 0, 255})
 	ImgMobB = generateMobImage(color.RGBA{255, 128, 0, 255})
 	ImgFootman = generateMobImage(color.RGBA{0, 0, 255, 255})
+	ImgArcherUnit = generateMobImage(color.RGBA{0, 160, 255, 255})
+	ImgKnight = generateMobImage(color.RGBA{40, 40, 140, 255})
+	ImgHealer = generateMobImage(color.RGBA{240, 240, 255, 255})
+	ImgHero = generateMobImage(color.RGBA{255, 200, 40, 255})
 	ImgProjectile = generateProjectileImage()
 	ImgBackgroundBasicTiles = generateBackground()
 }
@@ -0,0 +1,227 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cost is a price in any mix of resources. The zero Cost is free.
+type Cost struct {
+	Gold  int `json:"gold,omitempty" yaml:"gold"`
+	Food  int `json:"food,omitempty" yaml:"food"`
+	Wood  int `json:"wood,omitempty" yaml:"wood"`
+	Stone int `json:"stone,omitempty" yaml:"stone"`
+	Iron  int `json:"iron,omitempty" yaml:"iron"`
+	Mana  int `json:"mana,omitempty" yaml:"mana"`
+	Kings int `json:"kings,omitempty" yaml:"kings"`
+}
+
+// Plus returns the sum of both costs.
+func (c Cost) Plus(o Cost) Cost {
+	return Cost{c.Gold + o.Gold, c.Food + o.Food, c.Wood + o.Wood, c.Stone + o.Stone, c.Iron + o.Iron, c.Mana + o.Mana, c.Kings + o.Kings}
+}
+
+// Minus returns c without o.
+func (c Cost) Minus(o Cost) Cost {
+	return c.Plus(o.Times(-1))
+}
+
+// Times returns the cost multiplied by n.
+func (c Cost) Times(n int) Cost {
+	return Cost{c.Gold * n, c.Food * n, c.Wood * n, c.Stone * n, c.Iron * n, c.Mana * n, c.Kings * n}
+}
+
+// Percent returns pct percent of every resource, rounded down.
+func (c Cost) Percent(pct int) Cost {
+	return Cost{c.Gold * pct / 100, c.Food * pct / 100, c.Wood * pct / 100, c.Stone * pct / 100, c.Iron * pct / 100, c.Mana * pct / 100, c.Kings * pct / 100}
+}
+
+// IsZero reports whether the cost is free.
+func (c Cost) IsZero() bool { return c == Cost{} }
+
+// negative reports whether any resource is below zero.
+func (c Cost) negative() bool {
+	return c.Gold < 0 || c.Food < 0 || c.Wood < 0 || c.Stone < 0 || c.Iron < 0 || c.Mana < 0 || c.Kings < 0
+}
+
+// String lists the resources using the HUD icon letters, e.g. "20G 2W 2S",
+// or "free".
+func (c Cost) String() string {
+	var parts []string
+	for _, p := range []struct {
+		n     int
+		label string
+	}{{c.Gold, "G"}, {c.Wood, "W"}, {c.Stone, "S"}, {c.Iron, "I"}, {c.Food, "F"}, {c.Mana, "M"}, {c.Kings, "KP"}} {
+		if p.n != 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", p.n, p.label))
+		}
+	}
+	if len(parts) == 0 {
+		return "free"
+	}
+	return strings.Join(parts, " ")
+}
+
+// CanAfford reports whether the pool holds every resource of the cost.
+func (r *ResourcePool) CanAfford(c Cost) bool {
+	return r.Gold.Amount() >= c.Gold && r.Food.Amount() >= c.Food && r.Wood.Amount() >= c.Wood &&
+		r.Stone.Amount() >= c.Stone && r.Iron.Amount() >= c.Iron && r.Mana.Amount() >= c.Mana && r.Kings.Amount() >= c.Kings
+}
+
+// Spend deducts the whole cost if the pool can afford it and returns true.
+// Nothing is spent otherwise.
+func (r *ResourcePool) Spend(c Cost) bool {
+	if !r.CanAfford(c) {
+		return false
+	}
+	r.Gold.Spend(c.Gold)
+	r.Food.Spend(c.Food)
+	r.Wood.Spend(c.Wood)
+	r.Stone.Spend(c.Stone)
+	r.Iron.Spend(c.Iron)
+	r.Mana.Spend(c.Mana)
+	r.Kings.Spend(c.Kings)
+	return true
+}
+
+// Refund adds every resource of the cost back to the pool.
+func (r *ResourcePool) Refund(c Cost) {
+	r.Gold.Add(c.Gold)
+	r.Food.Add(c.Food)
+	r.Wood.Add(c.Wood)
+	r.Stone.Add(c.Stone)
+	r.Iron.Add(c.Iron)
+	r.Mana.Add(c.Mana)
+	r.Kings.Add(c.Kings)
+}
+
+// Costs prices the purchases that are not set elsewhere. Tower gold stays
+// tower_construction_cost and unit costs live in the roster.
+type Costs struct {
+	Tower    Cost         `json:"tower"`    // added to the gold of every new tower
+	Upgrade  Cost         `json:"upgrade"`  // added to the price of every tower upgrade
+	Upgrades UpgradeCosts `json:"upgrades"` // price of each tower upgrade
+	House    Cost         `json:"house"`
+	Tech     Cost         `json:"tech"` // per tech stage, so later nodes cost more
+}
+
+// UpgradeCosts holds the price of each tower upgrade.
+type UpgradeCosts struct {
+	Damage    Cost `json:"damage"`
+	Range     Cost `json:"range"`
+	FireRate  Cost `json:"fire_rate"`
+	Ammo      Cost `json:"ammo"`
+	Foresight Cost `json:"foresight"`
+	Munitions Cost `json:"munitions"`
+}
+
+// list returns the prices in upgrade menu order.
+func (u UpgradeCosts) list() []Cost {
+	return []Cost{u.Damage, u.Range, u.FireRate, u.Ammo, u.Foresight, u.Munitions}
+}
+
+// DefaultCosts makes towers need Wood and Stone and upgrades need Iron.
+var DefaultCosts = Costs{
+	Tower:   Cost{Wood: 2, Stone: 2},
+	Upgrade: Cost{Iron: 1},
+	Upgrades: UpgradeCosts{
+		Damage:    Cost{Gold: 5},
+		Range:     Cost{Gold: 5},
+		FireRate:  Cost{Gold: 5},
+		Ammo:      Cost{Gold: 10},
+		Foresight: Cost{Gold: 5},
+		Munitions: Cost{Gold: 15},
+	},
+	House: Cost{Gold: 15, Wood: 4},
+	Tech:  Cost{Gold: 5, Stone: 1, Iron: 1},
+}
+
+// validateCosts rejects negative prices.
+func validateCosts(c Costs) error {
+	prices := map[string]Cost{"tower": c.Tower, "upgrade": c.Upgrade, "house": c.House, "tech": c.Tech}
+	for i, cost := range c.Upgrades.list() {
+		prices[towerUpgrades[i].Name+" upgrade"] = cost
+	}
+	for name, cost := range prices {
+		if cost.negative() {
+			return fmt.Errorf("costs: %s cost %v is negative", name, cost)
+		}
+	}
+	return nil
+}
+
+// towerUpgrade is a tower upgrade offered by the upgrade menu and the shop.
+type towerUpgrade struct {
+	Name  string
+	apply func(t *Tower)
+}
+
+// towerUpgrades lists the upgrades in menu order, matching
+// UpgradeCosts.list. The shop offers all but the last.
+var towerUpgrades = []towerUpgrade{
+	{"Damage", (*Tower).UpgradeDamage},
+	{"Range", (*Tower).UpgradeRange},
+	{"Fire Rate", (*Tower).UpgradeFireRate},
+	{"Ammo", func(t *Tower) { t.UpgradeAmmoCapacity(2) }},
+	{"Foresight", func(t *Tower) { t.UpgradeForesight(2) }},
+	{"Munitions", (*Tower).UpgradeProjectile},
+}
+
+// towerConstructionCost returns the configured gold cost of a new tower.
+func (g *Game) towerConstructionCost() int {
+	cost := DefaultConfig.TowerConstructionCost
+	if g.cfg != nil && g.cfg.TowerConstructionCost != 0 {
+		cost = g.cfg.TowerConstructionCost
+	}
+	return cost
+}
+
+// costs returns the configured prices.
+func (g *Game) costs() Costs {
+	if g.cfg == nil {
+		return DefaultCosts
+	}
+	return g.cfg.Costs
+}
+
+// upgradeCost returns the price of the i-th tower upgrade.
+func (g *Game) upgradeCost(i int) Cost {
+	c := g.costs()
+	return c.Upgrades.list()[i].Plus(c.Upgrade)
+}
+
+// buyUpgrade pays for the i-th upgrade of t and applies it.
+func (g *Game) buyUpgrade(t *Tower, i int) bool {
+	cost := g.upgradeCost(i)
+	if !g.resources.Spend(cost) {
+		return false
+	}
+	towerUpgrades[i].apply(t)
+	t.invested = t.invested.Plus(cost)
+	g.applySynergies()
+	return true
+}
+
+// techCost returns the price of buying the tech node at the given stage from
+// the tech menu. The first node is free.
+func (g *Game) techCost(stage int) Cost { return g.costs().Tech.Times(stage) }
+
+// upgradeMenuLines lists the first n tower upgrades with their prices,
+// marking those the player cannot afford.
+func (g *Game) upgradeMenuLines(n, cursor int) []string {
+	var lines []string
+	for i := 0; i < n; i++ {
+		lines = append(lines, g.costLine(i == cursor, fmt.Sprintf("%d %s", i+1, towerUpgrades[i].Name), g.upgradeCost(i)))
+	}
+	return lines
+}
+
+// costLine formats a menu entry with its price, marking the cursor and
+// prices the player cannot afford.
+func (g *Game) costLine(selected bool, label string, c Cost) string {
+	line := cursorLine(selected, fmt.Sprintf("%s - %s", label, c))
+	if !g.resources.CanAfford(c) {
+		line += " (need more)"
+	}
+	return line
+}
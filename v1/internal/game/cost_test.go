@@ -0,0 +1,111 @@
+package game
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCostArithmetic(t *testing.T) {
+	c := Cost{Gold: 20, Wood: 2, Stone: 3}
+	if got := c.Percent(50); got != (Cost{Gold: 10, Wood: 1, Stone: 1}) {
+		t.Errorf("half of %v should round down, got %v", c, got)
+	}
+	if got := c.Minus(c.Percent(25)); got != (Cost{Gold: 15, Wood: 2, Stone: 3}) {
+		t.Errorf("25%% off %v got %v", c, got)
+	}
+	if got := DefaultCosts.Tech.Times(2); got != (Cost{Gold: 10, Stone: 2, Iron: 2}) {
+		t.Errorf("tech stage 2 cost %v", got)
+	}
+	if c.String() != "20G 2W 3S" || (Cost{}).String() != "free" {
+		t.Errorf("unexpected cost text %q", c.String())
+	}
+}
+
+func TestPoolSpendIsAllOrNothing(t *testing.T) {
+	var r ResourcePool
+	r.AddGold(20)
+	r.AddWood(1)
+	if r.Spend(Cost{Gold: 5, Wood: 2}) {
+		t.Fatalf("spend should fail without enough wood")
+	}
+	if r.GoldAmount() != 20 || r.WoodAmount() != 1 {
+		t.Errorf("a failed spend must not take anything")
+	}
+	if !r.Spend(Cost{Gold: 5, Wood: 1}) || r.GoldAmount() != 15 || r.WoodAmount() != 0 {
+		t.Errorf("spend should take every resource")
+	}
+	r.Refund(Cost{Wood: 1, Kings: 2})
+	if r.WoodAmount() != 1 || r.KingsAmount() != 2 {
+		t.Errorf("refund should add every resource")
+	}
+}
+
+func TestTowerNeedsMaterials(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10)}
+	g.AddGold(100)
+	g.cursorX, g.cursorY = 10, 10
+	g.buildTowerAtCursorType(TowerBasic)
+	if len(g.towers) != 0 || g.Gold() != 100 {
+		t.Fatalf("a tower should need wood and stone")
+	}
+	g.resources.AddWood(2)
+	g.resources.AddStone(2)
+	g.buildTowerAtCursorType(TowerBasic)
+	if len(g.towers) != 1 || g.towers[0].invested != g.towerCost(TowerBasic) {
+		t.Fatalf("tower should be built and remember its cost")
+	}
+	g.resources.AddIron(1)
+	if !g.buyUpgrade(g.towers[0], 0) || g.resources.IronAmount() != 0 {
+		t.Fatalf("damage upgrade should cost gold and iron")
+	}
+	if g.buyUpgrade(g.towers[0], 0) {
+		t.Errorf("upgrade should need iron")
+	}
+	if want := (Cost{Gold: 25, Wood: 2, Stone: 2, Iron: 1}); g.towers[0].invested != want {
+		t.Errorf("invested %v, want %v", g.towers[0].invested, want)
+	}
+}
+
+func TestBarracksChargesUnitCost(t *testing.T) {
+	b := NewBarracks()
+	b.restore(2, nil, UnitArcher)
+	var pool ResourcePool
+	b.SetResources(&pool)
+	b.pendingWord = "fjfj"
+	if b.OnWordCompleted("fjfj") != nil {
+		t.Fatalf("archer should not train without wood")
+	}
+	pool.AddWood(3)
+	b.pendingWord = "fjfj"
+	if b.OnWordCompleted("fjfj") == nil || pool.WoodAmount() != 0 {
+		t.Errorf("archer should train for %v", b.TrainingCost())
+	}
+}
+
+func TestLoadConfigCosts(t *testing.T) {
+	tmp, err := os.CreateTemp("", "cfg*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+	tmp.WriteString(`{"costs":{"tower":{"wood":5},"house":{"gold":-1}}}`)
+	tmp.Close()
+	if _, err := LoadConfig(tmp.Name()); err == nil {
+		t.Errorf("negative costs should be rejected")
+	}
+	os.WriteFile(tmp.Name(), []byte(`{"costs":{"upgrades":{"ammo":{"gold":-1}}}}`), 0644)
+	if _, err := LoadConfig(tmp.Name()); err == nil {
+		t.Errorf("negative upgrade costs should be rejected")
+	}
+	os.WriteFile(tmp.Name(), []byte(`{"costs":{"tower":{"wood":5},"upgrades":{"ammo":{"gold":20}}}}`), 0644)
+	cfg, err := LoadConfig(tmp.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Costs.Tower != (Cost{Wood: 5, Stone: 2}) || cfg.Costs.House != DefaultCosts.House {
+		t.Errorf("unset costs should keep their defaults, got %+v", cfg.Costs)
+	}
+	if cfg.Costs.Upgrades.Ammo != (Cost{Gold: 20}) || cfg.Costs.Upgrades.Damage != DefaultCosts.Upgrades.Damage {
+		t.Errorf("upgrade prices should come from the config, got %+v", cfg.Costs.Upgrades)
+	}
+}
@@ -0,0 +1,131 @@
+package game
+
+import "fmt"
+
+// DefaultDifficulty is the profile used when none is chosen.
+const DefaultDifficulty = "Normal"
+
+// Mistake policies decide what a mistyped queue letter costs.
+const (
+	MistakeForgive = "forgive" // progress on the word resets, typing continues
+	MistakeJam     = "jam"     // the queue jams until Backspace is pressed
+	MistakeStrict  = "strict"  // the queue jams and the mistake costs gold
+)
+
+// strictMistakeGold is the gold lost per mistake under the strict policy.
+const strictMistakeGold = 1
+
+// defaultQueuePressure is the backlog length at which the queue starts
+// damaging the base.
+const defaultQueuePressure = 6
+
+// DifficultyProfile scales a run. Zero multipliers and amounts keep the
+// config values.
+type DifficultyProfile struct {
+	Name              string  `json:"name"`
+	MobHPMult         float64 `json:"mob_hp_mult"`
+	MobSpeedMult      float64 `json:"mob_speed_mult"`
+	SpawnIntervalMult float64 `json:"spawn_interval_mult"`
+	QueuePressure     int     `json:"queue_pressure"` // backlog length that damages the base
+	StartingGold      int     `json:"starting_gold"`
+	BaseHP            int     `json:"base_hp"`
+	MistakePolicy     string  `json:"mistake_policy"` // forgive, jam or strict
+	AffixScale        float64 `json:"affix_scale"`    // multiplier on affix roll chances
+}
+
+// DefaultDifficulties are the built-in Easy, Normal and Hard profiles.
+var DefaultDifficulties = []DifficultyProfile{
+	{Name: "Easy", MobHPMult: 0.75, MobSpeedMult: 0.85, SpawnIntervalMult: 1.25, QueuePressure: 8, StartingGold: 40, BaseHP: 15, MistakePolicy: MistakeForgive, AffixScale: 0.5},
+	{Name: "Normal", MobHPMult: 1, MobSpeedMult: 1, SpawnIntervalMult: 1, QueuePressure: defaultQueuePressure, MistakePolicy: MistakeJam, AffixScale: 1},
+	{Name: "Hard", MobHPMult: 1.5, MobSpeedMult: 1.2, SpawnIntervalMult: 0.8, QueuePressure: 4, BaseHP: 6, MistakePolicy: MistakeStrict, AffixScale: 2},
+}
+
+// Difficulty returns the profile with the given name. An empty name selects
+// the default difficulty.
+func (c Config) Difficulty(name string) (DifficultyProfile, bool) {
+	if name == "" {
+		name = DefaultDifficulty
+	}
+	for _, p := range c.Difficulties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return DifficultyProfile{}, false
+}
+
+// validateDifficulties checks profile names, values and mistake policies.
+func validateDifficulties(list []DifficultyProfile) error {
+	seen := map[string]bool{}
+	for i, p := range list {
+		if p.Name == "" {
+			return fmt.Errorf("difficulty %d has no name", i+1)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate difficulty %s", p.Name)
+		}
+		seen[p.Name] = true
+		if p.MobHPMult < 0 || p.MobSpeedMult < 0 || p.SpawnIntervalMult < 0 || p.QueuePressure < 0 ||
+			p.StartingGold < 0 || p.BaseHP < 0 || p.AffixScale < 0 {
+			return fmt.Errorf("difficulty %s: negative value", p.Name)
+		}
+		switch p.MistakePolicy {
+		case "", MistakeForgive, MistakeJam, MistakeStrict:
+		default:
+			return fmt.Errorf("difficulty %s: unknown mistake policy %q", p.Name, p.MistakePolicy)
+		}
+	}
+	return nil
+}
+
+// scale returns v times mult, or v when mult is unset.
+func scale(v, mult float64) float64 {
+	if mult > 0 {
+		return v * mult
+	}
+	return v
+}
+
+// Difficulty returns the name of the run's difficulty.
+func (g *Game) Difficulty() string { return g.difficulty.Name }
+
+// SetDifficulty selects the named profile for the rest of the run. Mob
+// scaling, spawn pacing, queue pressure and the mistake policy apply from
+// then on; starting gold and base health are applied by startRun.
+func (g *Game) SetDifficulty(name string) bool {
+	p, ok := g.cfg.Difficulty(name)
+	if !ok {
+		return false
+	}
+	g.difficulty = p
+	g.affixScale = scale(1, p.AffixScale)
+	if g.queue != nil {
+		g.queue.SetPressureThreshold(p.QueuePressure)
+	}
+	return true
+}
+
+// startRun applies the starting gold and base health of the difficulty and
+// the class kit to a fresh run.
+func (g *Game) startRun() {
+	p := g.difficulty
+	if p.StartingGold > 0 {
+		g.resources.Gold.Set(p.StartingGold)
+	}
+	if p.BaseHP > 0 && g.base != nil {
+		g.base.health = p.BaseHP
+	}
+	g.applyClassKit()
+}
+
+// penalizeMistake applies the difficulty's mistake policy after a mistyped
+// queue letter and reports whether the queue jams.
+func (g *Game) penalizeMistake() bool {
+	switch g.difficulty.MistakePolicy {
+	case MistakeForgive:
+		return false
+	case MistakeStrict:
+		g.SpendGold(strictMistakeGold)
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+//go:build test
+
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultDifficulties(t *testing.T) {
+	for _, name := range NewPreGame().diffOptions {
+		if _, ok := DefaultConfig.Difficulty(name); !ok {
+			t.Errorf("PreGame offers %s but config has no such profile", name)
+		}
+	}
+	if p, ok := DefaultConfig.Difficulty(""); !ok || p.Name != DefaultDifficulty {
+		t.Errorf("empty name should select %s", DefaultDifficulty)
+	}
+	if err := validateDifficulties(DefaultDifficulties); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigDifficulties(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.json")
+	os.WriteFile(path, []byte(`{"difficulties":[{"name":"Brutal","mob_hp_mult":3}]}`), 0644)
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := cfg.Difficulty("Brutal"); !ok || p.MobHPMult != 3 {
+		t.Errorf("custom profile not loaded: %+v", cfg.Difficulties)
+	}
+	if DefaultConfig.Difficulties[0].Name != "Easy" {
+		t.Errorf("loading a config must not change the default profiles")
+	}
+	os.WriteFile(path, []byte(`{"difficulties":[{"name":"X","mistake_policy":"explode"}]}`), 0644)
+	if _, err := LoadConfig(path); err == nil {
+		t.Errorf("unknown mistake policy should be rejected")
+	}
+}
+
+func TestConfigFileDifficulties(t *testing.T) {
+	cfg, err := LoadConfig("../../" + ConfigFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range DefaultDifficulties {
+		if got, ok := cfg.Difficulty(want.Name); !ok || got != want {
+			t.Errorf("%s: %s profile should mirror the default", ConfigFile, want.Name)
+		}
+	}
+}
+
+func TestPreGameAppliesDifficulty(t *testing.T) {
+	g := NewGame()
+	g.phase = PhasePreGame
+	g.preGame = NewPreGame()
+	g.preGame.step = 4
+	g.preGame.diffCursor = 2 // Hard
+	g.input = &pgInput{enter: true}
+	if err := g.preGame.Update(g, 0); err != nil {
+		t.Fatal(err)
+	}
+	if g.Difficulty() != "Hard" {
+		t.Fatalf("expected Hard, got %s", g.Difficulty())
+	}
+	if g.base.Health() != 6 {
+		t.Errorf("Hard base HP should be 6, got %d", g.base.Health())
+	}
+	if g.queue.pressure != 4 || g.affixScale != 2 {
+		t.Errorf("queue pressure %d affix scale %v", g.queue.pressure, g.affixScale)
+	}
+	if g.spawnInterval != g.cfg.SpawnInterval*6*0.8 {
+		t.Errorf("spawn interval should be scaled, got %v", g.spawnInterval)
+	}
+}
+
+func TestEasyStartingGold(t *testing.T) {
+	g := NewGame()
+	g.SetDifficulty("Easy")
+	g.startRun()
+	if g.Gold() != 40 || g.base.Health() != 15 {
+		t.Errorf("Easy should start with 40 gold and 15 HP, got %d %d", g.Gold(), g.base.Health())
+	}
+}
+
+func TestDifficultyScalesMobs(t *testing.T) {
+	spawn := func(name string) *Mob {
+		g := NewGame()
+		g.SetDifficulty(name)
+		g.mobs = nil
+		g.spawnMob(waveSpawn{group: WaveGroup{Mob: "basic", Count: 1}, hpScale: 1})
+		return g.mobs[0].(*Mob)
+	}
+	normal, hard := spawn("Normal"), spawn("Hard")
+	if hard.health <= normal.health || hard.speed <= normal.speed {
+		t.Errorf("Hard mobs should be tougher and faster: %d/%v vs %d/%v", hard.health, hard.speed, normal.health, normal.speed)
+	}
+}
+
+func TestMistakePolicies(t *testing.T) {
+	g := NewGame()
+	g.SetDifficulty("Easy")
+	if g.penalizeMistake() {
+		t.Errorf("forgive policy should not jam")
+	}
+	g.SetDifficulty("Hard")
+	g.AddGold(3)
+	if !g.penalizeMistake() || g.Gold() != 2 {
+		t.Errorf("strict policy should jam and cost gold, gold %d", g.Gold())
+	}
+}
+
+func TestSaveKeepsDifficulty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.json")
+	g := NewGame()
+	g.SetDifficulty("Hard")
+	g.saveGame(path)
+	l := NewGame()
+	if err := l.loadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if l.Difficulty() != "Hard" {
+		t.Errorf("loaded difficulty %s, want Hard", l.Difficulty())
+	}
+}
+
+func TestHistorySegregatedByDifficulty(t *testing.T) {
+	h := &PerformanceHistory{}
+	h.Records = []Performance{{WPM: 80, Accuracy: 0.9, Difficulty: "Easy"}, {WPM: 40, Accuracy: 0.95, Difficulty: "Hard"}}
+	if b := h.Best("Hard"); b.WPM != 40 || b.Accuracy != 0.95 {
+		t.Errorf("Hard leaderboard should ignore Easy runs, got %+v", b)
+	}
+	h.AddAchievements("Easy", []string{"Speed Demon"})
+	h.AddAchievements("Easy", []string{"Speed Demon", "Sharpshooter"})
+	if got := h.AchievementsFor("Easy"); len(got) != 2 {
+		t.Errorf("expected 2 Easy achievements, got %v", got)
+	}
+	if len(h.AchievementsFor("Hard")) != 0 {
+		t.Errorf("achievements should not leak across difficulties")
+	}
+}
+
+func TestPreGameOffersConfiguredDifficulties(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Difficulties = []DifficultyProfile{{Name: "Chill", BaseHP: 30}, {Name: "Brutal", MobHPMult: 3}}
+	g := NewGameWithConfig(cfg)
+	if got := g.preGame.diffOptions; len(got) != 2 || got[0] != "Chill" || got[1] != "Brutal" {
+		t.Fatalf("PreGame should offer the configured profiles in order, got %v", got)
+	}
+	g.preGame.step = 4
+	g.preGame.diffCursor = 1
+	g.input = &pgInput{enter: true}
+	if err := g.preGame.Update(g, 0); err != nil {
+		t.Fatal(err)
+	}
+	if g.Difficulty() != "Brutal" {
+		t.Errorf("expected Brutal, got %s", g.Difficulty())
+	}
+}
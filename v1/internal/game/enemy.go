@@ -10,6 +10,20 @@ const (
 	MobFast
 	MobWord
 	MobBoss
+	MobGrunt
+	MobArcher
+	MobShaman
+	MobCatapult
+	MobFlyer
+)
+
+// TargetLayer is the altitude an enemy moves at. Only anti-air attacks can
+// reach enemies in the air.
+type TargetLayer int
+
+const (
+	LayerGround TargetLayer = iota
+	LayerAir
 )
 
 // Enemy describes common enemy behavior.
@@ -19,4 +33,23 @@ type Enemy interface {
 	Alive() bool
 	Damage(amount int)
 	Type() MobType
+	Layer() TargetLayer
+}
+
+// asMob returns the Mob underlying an enemy, including mobs embedded in
+// bosses.
+func asMob(e Enemy) (*Mob, bool) {
+	switch m := e.(type) {
+	case *Mob:
+		return m, true
+	case *Boss:
+		return m.Mob, true
+	}
+	return nil, false
+}
+
+// canHit reports whether an attack, with or without anti-air capability, can
+// damage the enemy.
+func canHit(e Enemy, antiAir bool) bool {
+	return shootable(e) && (antiAir || e.Layer() != LayerAir)
 }
@@ -1,65 +1,56 @@
 package game
 
-import "github.com/hajimehoshi/ebiten/v2"
+const (
+	footmanAggroRadius = 200.0 // pixels within which a unit seeks grunts
+	footmanLeash       = 320.0 // pixels from the rally point a chase may reach
+	footmanHoldRadius  = 64.0  // pixels around a held chokepoint that are defended
+	footmanSeparation  = 24.0  // pixels units keep between each other
+	footmanArrive      = 16.0  // pixels from the rally point that count as there, room for a formation
+)
+
+// Stance decides how far a unit strays from its rally point.
+type Stance int
+
+const (
+	// StanceRally engages grunts within the aggro radius and returns to the
+	// rally point once they are dead or out of reach.
+	StanceRally Stance = iota
+	// StanceHold keeps the unit at its point, defending only the area
+	// around it.
+	StanceHold
+	// StanceCharge sends the unit after the nearest grunt anywhere on the
+	// field.
+	StanceCharge
+	// StanceRetreat walks the unit back to its rally point without
+	// fighting.
+	StanceRetreat
+)
+
+// String returns the order name of the stance.
+func (s Stance) String() string {
+	switch s {
+	case StanceHold:
+		return "Hold"
+	case StanceCharge:
+		return "Charge"
+	case StanceRetreat:
+		return "Retreat"
+	}
+	return "Rally"
+}
 
 // Footman represents a simple melee unit spawned from the Barracks with basic
 // combat stats.
-type Footman struct {
-	BaseEntity
-	hp     int     // current hit points
-	damage int     // melee damage dealt when attacking
-	speed  float64 // movement speed in pixels/sec
-	alive  bool    // whether the unit is active
-}
+type Footman struct{ soldier }
 
-// NewFootman creates a Footman at the given position.
+// NewFootman creates a Footman with the default roster stats at the given
+// position. It rallies where it was created until ordered elsewhere.
 func NewFootman(x, y float64) *Footman {
-	w, h := ImgFootman.Bounds().Dx(), ImgFootman.Bounds().Dy()
-	return &Footman{
-		BaseEntity: BaseEntity{
-			pos:          Point{x, y},
-			width:        w,
-			height:       h,
-			frame:        ImgFootman,
-			frameAnchorX: float64(w) / 2,
-			frameAnchorY: float64(h) / 2,
-		},
-		hp:     10,
-		damage: 1,
-		speed:  50,
-		alive:  true,
-	}
-}
-
-// Update moves the Footman to the right.
-// Update moves the Footman to the right and checks if it is still alive.
-func (f *Footman) Update(dt float64) error {
-	if !f.alive {
-		return nil
-	}
-	f.pos.X += f.speed * dt
-	if f.hp <= 0 {
-		f.alive = false
-	}
-	return nil
-}
-
-// Alive reports whether the Footman is still active.
-func (f *Footman) Alive() bool { return f.alive }
-
-// Damage reduces the Footman's HP.
-func (f *Footman) Damage(amount int) {
-	if !f.alive {
-		return
-	}
-	f.hp -= amount
-	if f.hp <= 0 {
-		f.alive = false
-	}
+	return &Footman{soldier: newSoldier(defaultUnits[0], ImgFootman, x, y)}
 }
 
-// Health returns the Footman's current HP.
-func (f *Footman) Health() int { return f.hp }
+// melee hits a touching enemy.
+func (f *Footman) melee(o foe) { f.strikeMelee(o) }
 
-// Frame satisfies the Entity interface for Footman.
-func (f *Footman) Frame() *ebiten.Image { return f.frame }
+// act does nothing: Footmen only fight in melee.
+func (f *Footman) act(m *Military, dt float64) {}
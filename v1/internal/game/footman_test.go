@@ -1,14 +1,96 @@
 package game
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestFootmanMovement(t *testing.T) {
 	f := NewFootman(0, 0)
 	f.speed = 10
 	f.Update(1.0)
-	x, _ := f.Position()
-	if x <= 0 {
-		t.Errorf("expected footman to move right, got %f", x)
+	if x, _ := f.Position(); x != 0 {
+		t.Errorf("an idle footman should stay at its rally point, got x=%f", x)
+	}
+	f.SetRally(Point{100, 0}, StanceRally)
+	f.Update(1.0)
+	if x, _ := f.Position(); x != 10 {
+		t.Errorf("expected footman to walk 10px towards its rally point, got %f", x)
+	}
+}
+
+func TestFootmanEngagesWithinAggro(t *testing.T) {
+	m := NewMilitary()
+	f := NewFootman(0, 0)
+	m.AddUnit(f)
+	near := NewOrcGrunt(150, 0)
+	far := NewOrcGrunt(-400, 0)
+	near.speed, far.speed = 0, 0
+	m.Update(0.1, []*OrcGrunt{far, near})
+	if f.target != near {
+		t.Fatalf("footman should target the grunt within aggro radius")
+	}
+	near.alive = false
+	m.Update(0.1, []*OrcGrunt{far, near})
+	if f.target != nil {
+		t.Errorf("footman should ignore grunts beyond its aggro radius")
+	}
+}
+
+func TestFootmanReturnsToRally(t *testing.T) {
+	m := NewMilitary()
+	f := NewFootman(100, 0)
+	m.AddUnit(f)
+	m.SetRally(Point{0, 0})
+	for i := 0; i < 30; i++ {
+		m.Update(0.1, nil)
+	}
+	if x, y := f.Position(); math.Hypot(x, y) > footmanArrive {
+		t.Errorf("footman should be back at the rally point, at %.1f,%.1f", x, y)
+	}
+}
+
+func TestFootmanHoldsChokepoint(t *testing.T) {
+	m := NewMilitary()
+	f := NewFootman(0, 0)
+	m.AddUnit(f)
+	m.Hold(Point{0, 0})
+	o := NewOrcGrunt(120, 0)
+	o.speed = 0
+	m.Update(0.1, []*OrcGrunt{o})
+	if f.target != nil {
+		t.Errorf("a holding footman should not chase grunts outside the chokepoint")
+	}
+	o.pos = Point{40, 0}
+	m.Update(0.1, []*OrcGrunt{o})
+	if f.target != o {
+		t.Errorf("a holding footman should fight grunts at the chokepoint")
+	}
+	late := NewFootman(300, 300)
+	m.AddUnit(late)
+	if p, s := late.Rally(); p != (Point{0, 0}) || s != StanceHold {
+		t.Errorf("new units should join the current order, got %v %v", p, s)
+	}
+}
+
+func TestFootmenSeparateDeterministically(t *testing.T) {
+	run := func() (Point, Point) {
+		m := NewMilitary()
+		a, b := NewFootman(0, 0), NewFootman(0, 0)
+		m.AddUnit(a)
+		m.AddUnit(b)
+		for i := 0; i < 10; i++ {
+			m.Update(0.1, nil)
+		}
+		return a.pos, b.pos
+	}
+	a1, b1 := run()
+	a2, b2 := run()
+	if a1 != a2 || b1 != b2 {
+		t.Fatalf("separation should be deterministic: %v %v vs %v %v", a1, b1, a2, b2)
+	}
+	if math.Hypot(a1.X-b1.X, a1.Y-b1.Y) < footmanSeparation/2 {
+		t.Errorf("stacked footmen should spread out, %v %v", a1, b1)
 	}
 }
 
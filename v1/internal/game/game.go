@@ -24,7 +24,7 @@ import (
 const jamFlashDuration = 0.15
 const conveyorSpeed = 200.0 // pixels per second for queue slide
 const letterWidth = 13.0    // approximate width of a character
-const SaveVersion = 1
+const SaveVersion = 4
 
 var (
 	mousePressed bool
@@ -37,23 +37,52 @@ var (
 )
 
 type savedTower struct {
-	X            float64
-	Y            float64
-	Damage       int
-	Range        float64
-	Rate         float64
-	AmmoCapacity int
+	X        float64
+	Y        float64
+	Type     TowerType
+	Level    int
+	Upgrades StatModifier
+	Invested Cost
 }
 
 type savedGame struct {
 	Version  int
 	Gold     int
 	Food     int
+	Wood     int
+	Stone    int
+	Iron     int
+	Kings    int
+	Mana     int
 	Wave     int
 	BaseHP   int
 	Towers   []savedTower
 	Settings Settings
 	Skills   []string
+
+	Difficulty string
+	Mode       string
+	Class      string
+	Hero       savedHero
+	Barracks   savedBarracks
+	Houses     [][2]int
+	Morale     float64
+}
+
+// savedHero is the hero's progress. It belongs to the hero of the saved
+// class.
+type savedHero struct {
+	Summoned bool
+	Level    int
+	XP       int
+	Ranks    map[string]int // ability word -> rank
+}
+
+// savedBarracks is the Barracks letter stage and the units it can train.
+type savedBarracks struct {
+	Stage    int
+	Units    []string // unlocked unit ids
+	Selected string   // unit the next word trains
 }
 
 // Game represents the game state and implements ebiten.Game interface.
@@ -66,6 +95,7 @@ type Game struct {
 	base        *Base
 	hud         *HUD
 	gameOver    bool
+	victory     bool // a Classic run survived every wave
 	paused      bool
 	resources   ResourcePool
 
@@ -79,17 +109,37 @@ type Game struct {
 	currentWave   int
 	spawnInterval float64
 	spawnTicker   float64
-	mobsToSpawn   int
+	waveScript    *WaveScript
+	pendingSpawns []waveSpawn // spawns of the current wave still to come
+	waveSize      int         // total spawns scheduled for the current wave
+	gameMap       *GameMap
+	bosses        *BossBook
+	affixes       *AffixBook
+	affixScale    float64           // difficulty multiplier on affix roll chances
+	difficulty    DifficultyProfile // chosen in PreGame, stored in saves
+	mode          string            // Classic or Endless
+	waveTimer     float64           // seconds since the current Endless wave began
+	class         *ClassDef         // chosen in PreGame, nil before a class is picked
+	abilityTimer  CooldownTimer     // class ability cooldown
+	abilityPhrase phraseSet         // class ability phrase being typed
+	typed         []rune            // letters left this frame for the queue and towers
+	pathVersion   int               // bumped whenever towers or walls change mob paths
+	wordTarget    *Mob              // word mob currently being typed
+	wordHeld      []rune            // letters typed into wordTarget so far
+	bossHeld      []rune            // letters spelling the start of a boss phrase
 
 	letterPool   []rune
+	drills       []ReloadDrill // reload drills unlocked through tech
 	unlockStage  int
 	techTree     *TechTree
 	skillTree    *SkillTree
 	achievements []string
-	towerMods    TowerModifiers
+	techMods     TowerModifiers
+	skillMods    TowerModifiers
 	wpmBonus     int
 	autoCollect  bool
 	hotkeys      bool
+	scouting     int // Scouting skill level, see scoutDepth
 
 	unlockedSkills map[string]bool
 	skillMenuOpen  bool
@@ -147,6 +197,18 @@ type Game struct {
 	miner      *Miner
 	barracks   *Barracks
 	military   *Military
+	hero       *Hero       // the run's hero, summoned by typing its name
+	houseTiles [][2]int    // houses raising the supply cap
+	buildings  []*Building // production buildings siege enemies can wreck
+	morale     float64     // army morale, falls when upkeep goes unpaid
+
+	waveWords    int          // wordHistory length when the wave started
+	bossKings    int          // King's Points from bosses killed this wave
+	waveReward   *kingsReward // King's Points earned in the last wave
+	summaryTimer float64      // seconds the Endless wave summary stays up
+
+	sanctum     *Sanctum
+	freezeTimer float64 // seconds enemies stay frozen by a Freeze spell
 
 	// Typing state for the queue - jam indicator
 	queueJam bool
@@ -154,10 +216,15 @@ type Game struct {
 	// Command mode for power users
 	commandMode   bool
 	commandBuffer string
+	shouting      bool   // letters go to the army shout instead of the queue
+	shoutTyped    string // letters of the shout typed so far
 
 	// Tower selection system
 	towerSelectMode bool
 	towerLabels     map[string]int // label -> tower index
+	towerAction     rune           // pending sell/move action key
+	moving          *towerMove     // active tower relocation, if any
+	lastBuilt       *Tower         // most recent build, for undo
 
 	// Static word processing location
 	wordProcessX float64
@@ -235,7 +302,6 @@ func NewGameWithHistory(cfg Config, hist *PerformanceHistory) *Game {
 		currentWave:     1,
 		spawnInterval:   cfg.SpawnInterval * 4.0, // Much slower spawning
 		spawnTicker:     0,
-		mobsToSpawn:     cfg.MobsPerWave,
 		cfg:             &cfg,
 		mobs:            make([]Enemy, 0),
 		projectiles:     make([]*Projectile, 0),
@@ -245,7 +311,8 @@ func NewGameWithHistory(cfg Config, hist *PerformanceHistory) *Game {
 		skillTree:       func() *SkillTree { t, _ := SampleSkillTree(); return t }(),
 		unlockedSkills:  make(map[string]bool),
 		achievements:    make([]string, 0),
-		towerMods:       TowerModifiers{DamageMult: 1, RangeMult: 1, FireRateMult: 1},
+		techMods:        TowerModifiers{DamageMult: 1, RangeMult: 1, FireRateMult: 1},
+		skillMods:       TowerModifiers{DamageMult: 1, RangeMult: 1, FireRateMult: 1},
 		wpmBonus:        0,
 		autoCollect:     false,
 		hotkeys:         false,
@@ -277,6 +344,7 @@ func NewGameWithHistory(cfg Config, hist *PerformanceHistory) *Game {
 		lumberjack:      NewLumberjack(),
 		miner:           NewMiner(),
 		barracks:        NewBarracks(),
+		sanctum:         NewSanctum(),
 		military:        NewMilitary(),
 		wordProcessX:    400,
 		wordProcessY:    900,
@@ -314,15 +382,31 @@ func NewGameWithHistory(cfg Config, hist *PerformanceHistory) *Game {
 	g.lumberjack.SetQueue(g.queue)
 	g.miner.SetQueue(g.queue)
 	g.barracks.SetQueue(g.queue)
+	g.sanctum.SetQueue(g.queue)
+	g.preGame.SetDifficulties(g.cfg.Difficulties)
 	g.barracks.SetMilitary(g.military)
+	g.barracks.SetResources(&g.resources)
+	g.barracks.SetSpawnPoint(g.base.pos)
+	g.buildings = newBuildings(g.base)
+	g.military.SetBase(g.base)
+	g.hero = NewHero(heroes[""])
+	g.setMorale(fullMorale)
+	g.refreshSupplyCap()
 
 	tx, ty = tilePosition(2, 16)
 	tower := NewTower(g, float64(tx+16), float64(ty+16))
-	tower.ApplyModifiers(g.towerMods)
 	g.towers = []*Tower{tower}
 	if tree, err := SampleSkillTree(); err == nil {
 		g.skillTree = tree
 	}
+	g.gameMap = DefaultMap()
+	g.pathVersion = 1
+	g.bosses = DefaultBosses()
+	g.affixes = DefaultAffixes()
+	g.affixScale = 1
+	g.SetDifficulty(DefaultDifficulty)
+	g.waveScript = DefaultWaveScript(cfg)
+	g.scheduleWave()
 	g.lastUpdate = time.Now()
 	g.hud = NewHUD(g)
 	return g
@@ -399,14 +483,7 @@ func (g *Game) Update() error {
 	}
 
 	if g.upgradeMenuOpen {
-		const (
-			upgradeDamageCost    = 5
-			upgradeRangeCost     = 5
-			upgradeFireRateCost  = 5
-			upgradeAmmoCost      = 10
-			upgradeForesightCost = 5
-			optionsCount         = 5
-		)
+		optionsCount := len(towerUpgrades)
 		if g.input.Down() {
 			g.upgradeCursor = (g.upgradeCursor + 1) % (optionsCount + 1)
 		}
@@ -415,39 +492,7 @@ func (g *Game) Update() error {
 		}
 		if len(g.towers) > 0 {
 			tower := g.towers[g.selectedTower]
-			purchase := func(opt int) bool {
-				switch opt {
-				case 0:
-					if g.SpendGold(upgradeDamageCost) {
-						tower.damage++
-						return true
-					}
-				case 1:
-					if g.SpendGold(upgradeRangeCost) {
-						tower.rangeDst += 50
-						tower.rangeImg = generateRangeImage(tower.rangeDst)
-						return true
-					}
-				case 2:
-					if g.SpendGold(upgradeFireRateCost) {
-						if tower.rate > 10 {
-							tower.rate -= 10
-						}
-						return true
-					}
-				case 3:
-					if g.SpendGold(upgradeAmmoCost) {
-						tower.UpgradeAmmoCapacity(2)
-						return true
-					}
-				case 4:
-					if g.SpendGold(upgradeForesightCost) {
-						tower.UpgradeForesight(2)
-						return true
-					}
-				}
-				return false
-			}
+			purchase := func(opt int) bool { return g.buyUpgrade(tower, opt) }
 
 			if inpututil.IsKeyJustPressed(ebiten.Key1) {
 				purchase(0)
@@ -464,6 +509,9 @@ func (g *Game) Update() error {
 			if inpututil.IsKeyJustPressed(ebiten.Key5) {
 				purchase(4)
 			}
+			if inpututil.IsKeyJustPressed(ebiten.Key6) {
+				purchase(5)
+			}
 
 			if g.input.Enter() {
 				if g.upgradeCursor < optionsCount {
@@ -480,30 +528,31 @@ func (g *Game) Update() error {
 		return nil
 	}
 
+	if g.moving != nil {
+		g.updateTowerMove()
+		return nil
+	}
+
 	if g.towerSelectMode {
 		for _, r := range g.input.TypedChars() {
-			label := strings.ToLower(string(r))
-			if idx, ok := g.towerLabels[label]; ok {
-				g.selectedTower = idx
-				g.towerSelectMode = false
-				g.upgradeMenuOpen = true
-				g.upgradeCursor = 0
+			if g.handleTowerSelectKey(r) {
 				break
 			}
 		}
 		if g.input.SelectTower() {
 			g.towerSelectMode = false
+			g.towerAction = 0
 		}
 		return nil
 	}
 
-	if !g.upgradeMenuOpen && !g.buildMenuOpen && !g.shopOpen && g.input.SelectTower() {
+	if !g.upgradeMenuOpen && !g.buildMenuOpen && g.input.SelectTower() {
 		g.enterTowerSelectMode()
 		return nil
 	}
 
 	if g.buildMenuOpen {
-		const optionsCount = 4
+		const optionsCount = 6
 		if g.input.Down() {
 			g.buildCursor = (g.buildCursor + 1) % optionsCount
 		}
@@ -522,6 +571,18 @@ func (g *Game) Update() error {
 			g.buildTowerAtCursorType(TowerRapid)
 			g.buildMenuOpen = false
 		}
+		if inpututil.IsKeyJustPressed(ebiten.Key4) {
+			g.buildTowerAtCursorType(TowerBanner)
+			g.buildMenuOpen = false
+		}
+		if inpututil.IsKeyJustPressed(ebiten.Key5) {
+			g.buildTowerAtCursorType(TowerBallista)
+			g.buildMenuOpen = false
+		}
+		if inpututil.IsKeyJustPressed(ebiten.Key6) {
+			g.buildHouseAtCursor()
+			g.buildMenuOpen = false
+		}
 		if g.input.Enter() {
 			switch g.buildCursor {
 			case 0:
@@ -530,6 +591,12 @@ func (g *Game) Update() error {
 				g.buildTowerAtCursorType(TowerSniper)
 			case 2:
 				g.buildTowerAtCursorType(TowerRapid)
+			case 3:
+				g.buildTowerAtCursorType(TowerBanner)
+			case 4:
+				g.buildTowerAtCursorType(TowerBallista)
+			case 5:
+				g.buildHouseAtCursor()
 			}
 			g.buildMenuOpen = false
 		}
@@ -540,30 +607,7 @@ func (g *Game) Update() error {
 	}
 
 	if !g.shopOpen {
-		if g.input.Left() {
-			g.cursorX--
-		}
-		if g.input.Right() {
-			g.cursorX++
-		}
-		if g.input.Up() {
-			g.cursorY--
-		}
-		if g.input.Down() {
-			g.cursorY++
-		}
-		if g.cursorX < 0 {
-			g.cursorX = 0
-		}
-		if g.cursorX > 59 {
-			g.cursorX = 59
-		}
-		if g.cursorY < 0 {
-			g.cursorY = 0
-		}
-		if g.cursorY > 33 {
-			g.cursorY = 33
-		}
+		g.moveCursor()
 		if g.input.Build() {
 			g.buildMenuOpen = true
 			g.buildCursor = 0
@@ -574,6 +618,9 @@ func (g *Game) Update() error {
 		if err := g.reloadConfig(ConfigFile); err != nil {
 			fmt.Println("reload config:", err)
 		}
+		if err := g.reloadWaves(WavesFile); err != nil {
+			fmt.Println("reload waves:", err)
+		}
 	}
 
 	g.handleSlotMenuInput()
@@ -591,13 +638,18 @@ func (g *Game) Update() error {
 		return ebiten.Termination
 	}
 
-	if g.phase == PhaseGameOver {
+	if g.phase == PhaseGameOver || g.phase == PhaseVictory {
 		return nil
 	}
 
+	// Shouts, the class ability, hero words, word mobs and boss phrases take
+	// their letters first
+	g.typed = append([]rune{}, g.typeBossPhrases(g.typeWordMobs(g.typeHero(g.typeClassAbility(g.typeShouts(g.input.TypedChars())))))...)
+
 	// ---- Global typing queue processing (letter by letter) ----
 	if g.queue != nil {
 		g.queue.Update(dt)
+		typed := g.letters()
 		if _, ok := g.queue.Peek(); ok {
 			if g.queueJam {
 				if g.input.Backspace() {
@@ -605,7 +657,7 @@ func (g *Game) Update() error {
 					g.queue.ResetProgress()
 				}
 			} else {
-				for _, r := range g.input.TypedChars() {
+				for _, r := range typed {
 					match, done, dq := g.queue.TryLetter(r)
 					if match {
 						g.conveyorOffset += letterWidth
@@ -625,17 +677,21 @@ func (g *Game) Update() error {
 							switch dq.Source {
 							case "Farmer":
 								g.farmer.OnWordCompleted(dq.Text, &g.resources)
+							case repairWordSource:
+								g.repairTower(dq.Text)
+							case buildingRepairSource:
+								g.repairBuilding(dq.Text)
 							case "Barracks":
-								if unit := g.barracks.OnWordCompleted(dq.Text); unit != nil {
-									g.military.AddUnit(unit)
-								}
+								g.barracks.OnWordCompleted(dq.Text)
+							case sanctumSource:
+								g.castSpell(g.sanctum.OnWordCompleted(dq.Text))
 							}
 						}
 					} else {
 						g.typing.Record(false)
 						g.currentWord.Incorrect++
 						g.MistypeFeedback()
-						g.queueJam = true
+						g.queueJam = g.penalizeMistake()
 					}
 					break
 				}
@@ -653,14 +709,10 @@ func (g *Game) Update() error {
 	}
 
 	if g.shopOpen {
-		// Upgrade options and costs
+		// Tower upgrades first, then the building letter unlocks
 		const (
-			upgradeDamageCost    = 5
-			upgradeRangeCost     = 5
-			upgradeFireRateCost  = 5
-			upgradeAmmoCost      = 10
-			upgradeForesightCost = 5
-			optionsCount         = 8
+			shopUpgrades = 5
+			optionsCount = 8
 		)
 
 		if g.input.Down() {
@@ -674,35 +726,10 @@ func (g *Game) Update() error {
 			tower := g.towers[g.selectedTower]
 
 			purchase := func(opt int) bool {
+				if opt < shopUpgrades {
+					return g.buyUpgrade(tower, opt)
+				}
 				switch opt {
-				case 0:
-					if g.SpendGold(upgradeDamageCost) {
-						tower.damage++
-						return true
-					}
-				case 1:
-					if g.SpendGold(upgradeRangeCost) {
-						tower.rangeDst += 50
-						tower.rangeImg = generateRangeImage(tower.rangeDst)
-						return true
-					}
-				case 2:
-					if g.SpendGold(upgradeFireRateCost) {
-						if tower.rate > 10 {
-							tower.rate -= 10
-						}
-						return true
-					}
-				case 3:
-					if g.SpendGold(upgradeAmmoCost) {
-						tower.UpgradeAmmoCapacity(2)
-						return true
-					}
-				case 4:
-					if g.SpendGold(upgradeForesightCost) {
-						tower.UpgradeForesight(2)
-						return true
-					}
 				case 5:
 					if g.farmer != nil {
 						return g.farmer.UnlockNext(&g.resources)
@@ -752,17 +779,16 @@ func (g *Game) Update() error {
 		return nil
 	}
 
-	// Slow down mob spawning significantly
-	if g.mobsToSpawn > 0 {
-		g.spawnTicker += dt
-		if g.spawnTicker >= g.spawnInterval {
-			g.spawnTicker = 0
-			g.spawnMob()
-			g.mobsToSpawn--
-		}
+	if g.endless() {
+		g.updateEndless(dt)
+	} else if len(g.pendingSpawns) > 0 {
+		g.updateSpawns(dt)
+	} else if g.classicComplete() {
+		g.winRun()
 	} else if len(g.mobs) == 0 {
 		if !g.shopOpen {
 			g.shopOpen = true
+			g.awardWaveKings()
 			if g.lastWaveSaved != g.currentWave {
 				g.saveGame(g.currentSavePath())
 				g.lastWaveSaved = g.currentWave
@@ -772,31 +798,35 @@ func (g *Game) Update() error {
 
 	// Update buildings and units
 	if g.military != nil {
-		// Combat resolution currently only handles OrcGrunts; none are
-		// spawned yet so pass nil.
-		g.military.Update(dt, nil)
+		g.military.Update(dt, g.grunts())
 	}
-	if g.farmer != nil {
+	if g.farmer != nil && !g.silenced("Farmer") {
 		if w := g.farmer.Update(dt); w != "" {
 			g.farmer.OnWordCompleted(w, &g.resources)
 		}
 	}
-	if g.lumberjack != nil {
+	if g.lumberjack != nil && !g.silenced("Lumberjack") {
 		if w := g.lumberjack.Update(dt); w != "" {
 			g.lumberjack.OnWordCompleted(w, &g.resources)
 		}
 	}
-	if g.miner != nil {
+	if g.miner != nil && !g.silenced("Miner") {
 		if w := g.miner.Update(dt); w != "" {
 			g.miner.OnWordCompleted(w, &g.resources)
 		}
 	}
-	if g.barracks != nil {
+	if g.barracks != nil && !g.silenced("Barracks") && g.resources.CanAfford(g.barracks.TrainingCost()) {
 		if w := g.barracks.Update(dt); w != "" {
 			g.barracks.OnWordCompleted(w)
 		}
 	}
+	if g.sanctum != nil && !g.silenced("Sanctum") {
+		g.sanctum.Update(dt, &g.resources)
+	}
+	g.freezeTimer = math.Max(0, g.freezeTimer-dt)
 
+	g.abilityTimer.Tick(dt)
+	g.updateHero(dt)
 	for _, t := range g.towers {
 		t.Update(dt)
 	}
@@ -813,21 +843,42 @@ func (g *Game) Update() error {
 		i++
 	}
 
+	if !g.frozen() {
+		g.updateRangedAttacks(dt)
+	}
+	g.applyHasteAuras()
 	for i := 0; i < len(g.mobs); {
 		m := g.mobs[i]
-		m.Update(dt)
+		g.reroute(m)
+		if !g.frozen() {
+			m.Update(dt)
+		}
 		bx, by, bw, bh := g.base.Bounds()
 		mx, my := m.Position()
 		_, _, mw, _ := m.Bounds()
 		dx := mx - float64(bx+bw/2)
 		dy := my - float64(by+bh/2)
-		if math.Hypot(dx, dy) < float64(mw/2+bw/2) {
+		// Grunts stay and attack the base; everything else dies on contact
+		breached := false
+		if _, grunt := m.(*OrcGrunt); !grunt && math.Hypot(dx, dy) < float64(mw/2+bw/2) {
+			breached = true
 			g.base.Damage(1)
-			m.Damage(mw) // force kill
+			if mob, ok := asMob(m); ok {
+				mob.kill()
+			} else {
+				m.Damage(mw) // force kill
+			}
 		}
 		if !m.Alive() {
 			g.mobs = append(g.mobs[:i], g.mobs[i+1:]...)
+			if _, boss := m.(*Boss); boss && !breached {
+				g.creditBossKill()
+			}
 			mult := g.typing.ScoreMultiplier()
+			if mob, ok := m.(*Mob); ok {
+				mult *= mob.rewardMultiplier()
+				g.splitMob(mob)
+			}
 			reward := int(mult)
 			if reward < 1 {
 				reward = 1
@@ -846,9 +897,12 @@ func (g *Game) Update() error {
 
 	if g.gameOver && !g.gameOverHandled {
 		if g.history != nil {
-			g.history.Record(g.typing)
+			g.history.RecordRun(g.typing, Performance{Difficulty: g.difficulty.Name, Mode: g.Mode(), Score: g.score, Wave: g.currentWave})
 		}
 		g.evaluatePerformanceAchievements()
+		if g.history != nil {
+			g.history.AddAchievements(g.difficulty.Name, g.achievements)
+		}
 		g.gameOverHandled = true
 	}
 
@@ -927,25 +981,31 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		return
 	}
 	drawBackgroundTilemap(g.screen)
+	g.drawMap(g.screen)
+	g.drawHouses(g.screen)
 
-	if g.phase == PhaseGameOver {
+	if g.phase == PhaseGameOver || g.phase == PhaseVictory {
+		title := "Game Over"
+		if g.victory {
+			title = "Victory!"
+		}
 		opts := &text.DrawOptions{}
 		opts.GeoM.Translate(900, 540)
 		opts.ColorScale.ScaleWithColor(color.White)
-		text.Draw(g.screen, "Game Over", BoldFont, opts)
-		summary := []string{
-			fmt.Sprintf("Score: %d", g.score),
-			fmt.Sprintf("Accuracy: %.0f%%", g.typing.Accuracy()*100),
-			fmt.Sprintf("WPM: %.1f", g.EffectiveWPM()),
-		}
+		text.Draw(g.screen, title, BoldFont, opts)
+		summary := g.runSummary()
+		summary = append(summary, "Mode: "+g.Mode(), "Difficulty: "+g.difficulty.Name)
+		achievements := g.achievements
 		if g.history != nil {
+			best := g.history.Best(g.difficulty.Name)
 			summary = append(summary,
-				fmt.Sprintf("Best Accuracy: %.0f%%", g.history.BestAccuracy*100),
-				fmt.Sprintf("Best WPM: %.1f", g.history.BestWPM))
+				fmt.Sprintf("Best Accuracy (%s): %.0f%%", g.difficulty.Name, best.Accuracy*100),
+				fmt.Sprintf("Best WPM (%s): %.1f", g.difficulty.Name, best.WPM))
+			achievements = g.history.AchievementsFor(g.difficulty.Name)
 		}
-		if len(g.achievements) > 0 {
-			summary = append(summary, "Achievements:")
-			for _, a := range g.achievements {
+		if len(achievements) > 0 {
+			summary = append(summary, "Achievements ("+g.difficulty.Name+"):")
+			for _, a := range achievements {
 				summary = append(summary, " - "+a)
 			}
 		}
@@ -963,6 +1023,9 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	}
 
 	g.base.Draw(g.screen)
+	for _, b := range g.buildings {
+		b.Draw(g.screen)
+	}
 
 	for i, t := range g.towers {
 		t.Draw(g.screen)
@@ -1004,10 +1067,14 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		}
 	}
 
-	if !g.shopOpen {
+	if !g.shopOpen || g.moving != nil {
 		op := &ebiten.DrawImageOptions{}
 		op.GeoM.Translate(float64(g.cursorX*TileSize), float64(TopMargin+g.cursorY*TileSize))
 		if g.validTowerPosition(g.cursorX, g.cursorY) {
+			// Preview which tiles gain a synergy from this placement
+			for _, tile := range g.synergyPreview(g.cursorX, g.cursorY, g.previewTowerType()) {
+				vector.DrawFilledRect(g.screen, float32(tile[0]*TileSize), float32(TopMargin+tile[1]*TileSize), float32(TileSize), float32(TileSize), color.RGBA{255, 215, 0, 70}, false)
+			}
 			g.screen.DrawImage(ImgHighlightTile, op)
 		} else {
 			// draw red rectangle for invalid position
@@ -1083,40 +1150,6 @@ func drawBackgroundTilemap(screen *ebiten.Image) {
 	screen.DrawImage(ImgBackgroundBasicTiles, nil)
 }
 
-// spawnMob adds a new mob at the right side.
-func (g *Game) spawnMob() {
-	row := rand.Intn(32)
-	x, y := tilePosition(59, row)
-	hp := g.cfg.MobBaseHealth
-	if hp == 0 {
-		hp = 1
-	}
-	if g.cfg != nil {
-		hp = int(float64(hp) + float64(g.currentWave-1)*g.cfg.N)
-		if hp < 1 {
-			hp = 1
-		}
-	}
-	speed := g.cfg.MobSpeed * 0.3 // Much slower mobs
-	if speed == 0 {
-		speed = DefaultConfig.MobSpeed * 0.3
-	}
-	var m Enemy
-	if g.currentWave%5 == 0 && g.mobsToSpawn == 1 {
-		m = NewBossMob(float64(x+16), float64(y+16), g.base, hp*5, speed*0.5)
-	} else {
-		switch rand.Intn(3) {
-		case 0:
-			m = NewMob(float64(x+16), float64(y+16), g.base, hp, speed)
-		case 1:
-			m = NewArmoredMob(float64(x+16), float64(y+16), g.base, hp, 2, speed)
-		default:
-			m = NewFastMob(float64(x+16), float64(y+16), g.base, hp, speed, 2)
-		}
-	}
-	g.mobs = append(g.mobs, m)
-}
-
 func (g *Game) validTowerPosition(tileX, tileY int) bool {
 	if tileX < 0 || tileX > 59 || tileY < 0 || tileY > 33 {
 		return false
@@ -1134,18 +1167,39 @@ func (g *Game) validTowerPosition(tileX, tileY int) bool {
 			return false
 		}
 	}
-	return true
+	if g.isHouse(tileX, tileY) {
+		return false
+	}
+	if g.gameMap.IsWall(tileX, tileY) || g.gameMap.isWaypoint(tileX, tileY) {
+		return false
+	}
+	return !g.sealsBase(tileX, tileY)
+}
+
+// previewTowerType returns the tower type highlighted in the build menu, or a
+// basic tower when the menu is closed.
+func (g *Game) previewTowerType() TowerType {
+	if g.buildMenuOpen {
+		switch g.buildCursor {
+		case 1:
+			return TowerSniper
+		case 2:
+			return TowerRapid
+		case 3:
+			return TowerBanner
+		case 4:
+			return TowerBallista
+		}
+	}
+	return TowerBasic
 }
 
 func (g *Game) buildTowerAtCursorType(tt TowerType) {
 	if g.cfg == nil {
 		return
 	}
-	cost := g.cfg.TowerConstructionCost
-	if cost == 0 {
-		cost = DefaultConfig.TowerConstructionCost
-	}
-	if g.Gold() < cost {
+	cost := g.towerCost(tt)
+	if !g.resources.CanAfford(cost) {
 		return
 	}
 	if !g.validTowerPosition(g.cursorX, g.cursorY) {
@@ -1153,9 +1207,12 @@ func (g *Game) buildTowerAtCursorType(tt TowerType) {
 	}
 	tx, ty := tilePosition(g.cursorX, g.cursorY)
 	t := NewTowerWithType(g, float64(tx+TileSize/2), float64(ty+TileSize/2), tt)
-	t.ApplyModifiers(g.towerMods)
+	t.invested = cost
 	g.towers = append(g.towers, t)
-	g.SpendGold(cost)
+	g.lastBuilt = t
+	g.resources.Spend(cost)
+	g.applySynergies()
+	g.invalidatePaths()
 }
 
 // applyNextTech unlocks the next tech node and applies its effects.
@@ -1163,7 +1220,12 @@ func (g *Game) applyNextTech() {
 	if g.techTree == nil || g.techTree.Completed() {
 		return
 	}
+	node := g.techTree.nodes[g.techTree.stage]
+	drills := node.Drills
 	letters, ach, mods := g.techTree.UnlockNext()
+	if g.sanctum != nil {
+		g.sanctum.Book().Study(node.Name)
+	}
 	if len(letters) > 0 {
 		existing := make(map[rune]struct{})
 		for _, r := range g.letterPool {
@@ -1175,33 +1237,47 @@ func (g *Game) applyNextTech() {
 			}
 		}
 	}
-	if mods != (TowerModifiers{}) {
-		g.towerMods = g.towerMods.Merge(mods)
+	if g.sanctum != nil && len(g.letterPool) > 0 {
+		g.sanctum.SetLetterPool(g.letterPool)
+	}
+	for _, d := range drills {
+		g.drills = append(g.drills, d)
 		for _, t := range g.towers {
-			t.ApplyModifiers(mods)
+			t.AddDrill(d)
 		}
 	}
+	if mods != (TowerModifiers{}) {
+		g.techMods = g.techMods.Merge(mods)
+		g.refreshTowerStats()
+	}
 	if ach != "" {
 		g.achievements = append(g.achievements, ach)
 	}
 }
 
+// refreshTowerStats derives every tower's stats again after a global
+// modifier source changed, then the synergies that depend on them.
+func (g *Game) refreshTowerStats() {
+	for _, t := range g.towers {
+		t.recomputeStats()
+	}
+	g.applySynergies()
+}
+
+// addSkillMods merges modifiers granted by a skill into the skill layer.
+func (g *Game) addSkillMods(mod TowerModifiers) {
+	g.skillMods = g.skillMods.Merge(mod)
+	g.refreshTowerStats()
+}
+
 // applySkillEffects applies the effects of a newly unlocked skill node.
 func (g *Game) applySkillEffects(n *SkillNode) {
 	for k, v := range n.Effects {
 		switch k {
 		case "damage_mult":
-			mod := TowerModifiers{DamageMult: v}
-			g.towerMods = g.towerMods.Merge(mod)
-			for _, t := range g.towers {
-				t.ApplyModifiers(mod)
-			}
+			g.addSkillMods(TowerModifiers{DamageMult: v})
 		case "fire_rate_mult":
-			mod := TowerModifiers{FireRateMult: v}
-			g.towerMods = g.towerMods.Merge(mod)
-			for _, t := range g.towers {
-				t.ApplyModifiers(mod)
-			}
+			g.addSkillMods(TowerModifiers{FireRateMult: v})
 		case "hp_add":
 			if g.base != nil {
 				g.base.health += int(v)
@@ -1212,6 +1288,16 @@ func (g *Game) applySkillEffects(n *SkillNode) {
 			g.autoCollect = true
 		case "hotkeys":
 			g.hotkeys = true
+		case "scouting":
+			g.scouting += int(v)
+		case "range_mult":
+			g.addSkillMods(TowerModifiers{RangeMult: v})
+		case "footman_hp":
+			if g.barracks != nil {
+				g.barracks.bonusHP += int(v)
+			}
+		case "ability_cooldown_mult":
+			g.scaleAbilityCooldown(v)
 		}
 	}
 }
@@ -1250,7 +1336,8 @@ func (g *Game) filteredTechNodes() []TechNode {
 			}
 			if g.input.Enter() {
 				node := nodes[g.techCursor]
-				if g.techTree.stage < len(g.techTree.nodes) && node.Name == g.techTree.nodes[g.techTree.stage].Name {
+				stage := g.techTree.stage
+				if stage < len(g.techTree.nodes) && node.Name == g.techTree.nodes[stage].Name && g.resources.Spend(g.techCost(stage)) {
 					g.applyNextTech()
 					g.techMenuOpen = false
 				}
@@ -1272,15 +1359,19 @@ func (g *Game) filteredTechNodes() []TechNode {
 // startWave initializes spawn counters for the next wave.
 func (g *Game) startWave() {
 	g.spawnTicker = 0
-	base := g.cfg.MobsPerWave
-	if base == 0 {
-		base = DefaultConfig.MobsPerWave
-	}
-	inc := g.cfg.MobsPerWaveInc
-	g.mobsToSpawn = base + inc*(g.currentWave-1)
+	g.lastBuilt = nil // builds can only be undone during the following shop
+	g.scheduleWave()
 	g.spawnInterval = g.cfg.SpawnInterval * 6.0 // Much slower spawning
+	g.spawnInterval = scale(g.spawnInterval, g.difficulty.SpawnIntervalMult)
+	if g.currentWave > 1 {
+		g.payUpkeep()
+	}
 
-	g.applyNextTech()
+	// Only the free first node comes with a wave, the rest are bought from
+	// the tech menu
+	if g.techTree != nil && g.techTree.stage == 0 {
+		g.applyNextTech()
+	}
 }
 
 // randomReloadLetter returns a random letter from the current letter pool.
@@ -1299,7 +1390,7 @@ func (g *Game) skillNodesByCategory(cat SkillCategory) []*SkillNode {
 	}
 	var out []*SkillNode
 	for _, n := range g.skillTree.Nodes {
-		if n.Category == cat {
+		if n.Category == cat && g.skillTree.Available(n) {
 			out = append(out, n)
 		}
 	}
@@ -1330,7 +1421,7 @@ func (g *Game) handleSkillMenuInput() {
 	if !g.skillMenuOpen {
 		return
 	}
-	categories := []SkillCategory{SkillOffense, SkillDefense, SkillTyping, SkillAutomation, SkillUtility}
+	categories := []SkillCategory{SkillOffense, SkillDefense, SkillTyping, SkillAutomation, SkillUtility, SkillClass}
 	if g.input.Right() {
 		g.skillCategory = (g.skillCategory + 1) % SkillCategory(len(categories))
 		g.skillCursor = 0
@@ -1410,13 +1501,14 @@ func (g *Game) enterTowerSelectMode() {
 	g.towerSelectMode = true
 }
 
-// MistypeFeedback triggers a red flash and "clank" sound for an incorrect key press.
-// The flash duration is controlled by jamFlashDuration.
+// MistypeFeedback triggers a red flash and "clank" sound for an incorrect key press
+// and heals reflective mobs. The flash duration is controlled by jamFlashDuration.
 func (g *Game) MistypeFeedback() {
 	if g == nil {
 		return
 	}
 	g.flashTimer = jamFlashDuration
+	g.reflectMistype()
 	if g.sound != nil {
 		g.sound.PlayClank()
 	}
@@ -1584,11 +1676,6 @@ func (g *Game) reloadConfig(path string) error {
 	if cfg.SpawnInterval > 0 {
 		g.spawnInterval = cfg.SpawnInterval
 	}
-	base := cfg.MobsPerWave
-	if base == 0 {
-		base = DefaultConfig.MobsPerWave
-	}
-	g.mobsToSpawn = base + cfg.MobsPerWaveInc*(g.currentWave-1)
 	return nil
 }
 
@@ -1597,19 +1684,44 @@ func (g *Game) saveGame(path string) {
 		Version:  SaveVersion,
 		Gold:     g.resources.GoldAmount(),
 		Food:     g.resources.FoodAmount(),
+		Wood:     g.resources.WoodAmount(),
+		Stone:    g.resources.StoneAmount(),
+		Iron:     g.resources.IronAmount(),
+		Kings:    g.resources.KingsAmount(),
+		Mana:     g.resources.ManaAmount(),
 		Wave:     g.currentWave,
 		BaseHP:   g.base.Health(),
 		Settings: g.settings,
 		Skills:   make([]string, 0, len(g.unlockedSkills)),
+
+		Difficulty: g.difficulty.Name,
+		Mode:       g.Mode(),
+		Class:      g.Class(),
+		Houses:     g.houseTiles,
+		Morale:     g.morale,
+		Hero: savedHero{
+			Summoned: g.hero.summoned,
+			Level:    g.hero.level,
+			XP:       g.hero.xp,
+			Ranks:    map[string]int{},
+		},
+		Barracks: savedBarracks{
+			Stage:    g.barracks.Stage(),
+			Units:    g.barracks.Trainable(),
+			Selected: g.barracks.Selected(),
+		},
+	}
+	for i, a := range g.hero.def.Abilities {
+		sg.Hero.Ranks[a.Word] = g.hero.ranks[i]
 	}
 	for _, t := range g.towers {
 		sg.Towers = append(sg.Towers, savedTower{
-			X:            t.pos.X,
-			Y:            t.pos.Y,
-			Damage:       t.damage,
-			Range:        t.rangeDst,
-			Rate:         t.rate,
-			AmmoCapacity: t.ammoCapacity,
+			X:        t.pos.X,
+			Y:        t.pos.Y,
+			Type:     t.towerType,
+			Level:    t.level,
+			Upgrades: t.upgrades,
+			Invested: t.invested,
 		})
 	}
 	for id := range g.unlockedSkills {
@@ -1633,10 +1745,25 @@ func (g *Game) loadGame(path string) error {
 	if sg.Version != SaveVersion {
 		return ErrSaveVersion
 	}
+	script, gm, bosses, affixes, roster := g.waveScript, g.gameMap, g.bosses, g.affixes, g.barracks.Roster()
 	*g = *NewGameWithConfig(*g.cfg)
+	g.barracks.SetResources(&g.resources) // the pool moved with the copy
+	g.SetMap(gm)
+	g.SetBosses(bosses)
+	g.SetAffixes(affixes)
+	g.SetRoster(roster)
+	g.SetDifficulty(sg.Difficulty)
+	g.SetMode(sg.Mode)
+	g.SetClass(sg.Class)
 	g.resources.Gold.Set(sg.Gold)
 	g.resources.Food.Set(sg.Food)
+	g.resources.Wood.Set(sg.Wood)
+	g.resources.Stone.Set(sg.Stone)
+	g.resources.Iron.Set(sg.Iron)
+	g.resources.Kings.Set(sg.Kings)
+	g.resources.Mana.Set(sg.Mana)
 	g.currentWave = sg.Wave
+	g.SetWaveScript(script)
 	g.base.health = sg.BaseHP
 	g.settings = sg.Settings
 	if g.sound != nil && g.settings.Mute {
@@ -1644,18 +1771,13 @@ func (g *Game) loadGame(path string) error {
 	}
 	g.towers = nil
 	for _, st := range sg.Towers {
-		t := NewTower(g, st.X, st.Y)
-		t.damage = st.Damage
-		t.rangeDst = st.Range
-		t.rangeImg = generateRangeImage(t.rangeDst)
-		t.rate = st.Rate
-		t.ammoCapacity = st.AmmoCapacity
-		t.ammoQueue = make([]bool, t.ammoCapacity)
-		for i := range t.ammoQueue {
-			t.ammoQueue[i] = true
-		}
+		t := NewTowerWithTypeAndLevel(g, st.X, st.Y, st.Type, st.Level)
+		t.upgrades = st.Upgrades
+		t.invested = st.Invested
+		t.recomputeStats()
 		g.towers = append(g.towers, t)
 	}
+	g.applySynergies()
 	for _, id := range sg.Skills {
 		if node, ok := g.skillTree.Nodes[id]; ok {
 			g.skillTree.unlocked[id] = true
@@ -1663,17 +1785,47 @@ func (g *Game) loadGame(path string) error {
 			g.applySkillEffects(node)
 		}
 	}
+	g.barracks.restore(sg.Barracks.Stage, sg.Barracks.Units, sg.Barracks.Selected)
+	g.houseTiles = sg.Houses
+	g.refreshSupplyCap()
+	if sg.Morale > 0 {
+		g.setMorale(sg.Morale)
+	}
+	if sg.Hero.Level > 0 {
+		g.hero.setProgress(sg.Hero.Level, sg.Hero.XP, sg.Hero.Ranks)
+	}
+	if sg.Hero.Summoned {
+		g.summonHero()
+	}
 	return nil
 }
 
+// Restart returns to the main menu with a fresh game that keeps the loaded
+// content and last choices. The run itself starts from the PreGame screens.
 func (g *Game) Restart() {
 	hist := g.history
+	script, gm, bosses, affixes, roster := g.waveScript, g.gameMap, g.bosses, g.affixes, g.barracks.Roster()
+	difficulty, mode, class := g.difficulty.Name, g.mode, g.Class()
 	*g = *NewGameWithHistory(*g.cfg, hist)
+	g.barracks.SetResources(&g.resources) // the pool moved with the copy
+	g.SetMap(gm)
+	g.SetBosses(bosses)
+	g.SetAffixes(affixes)
+	g.SetRoster(roster)
+	g.SetDifficulty(difficulty)
+	g.SetMode(mode)
+	g.SetClass(class)
+	g.SetWaveScript(script)
 }
 
-// executeCommand runs a textual command entered via command mode.
+// executeCommand runs a textual command entered via command mode. Commands
+// other than quit, pause, resume, train, hero and spell are army orders.
 func (g *Game) executeCommand(cmd string) {
-	switch strings.ToLower(cmd) {
+	args := strings.Fields(strings.ToLower(cmd))
+	if len(args) == 0 {
+		return
+	}
+	switch args[0] {
 	case "quit":
 		g.quit = true
 	case "pause":
@@ -1682,6 +1834,20 @@ func (g *Game) executeCommand(cmd string) {
 	case "resume":
 		g.paused = false
 		g.phase = PhasePlaying
+	case "train":
+		if len(args) > 1 {
+			g.barracks.Select(args[1])
+		}
+	case "hero":
+		if len(args) > 1 {
+			g.hero.RankUp(args[1])
+		}
+	case "spell":
+		if len(args) > 1 {
+			g.sanctum.Select(args[1])
+		}
+	default:
+		g.orderArmy(args)
 	}
 }
 
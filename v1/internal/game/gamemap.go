@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// MapFile is the default path for map data.
+const MapFile = "map.yaml"
+
+// Lane is a named route enemies can be sent down. The first waypoint is the
+// spawn tile; mobs visit every waypoint in order before heading to the base.
+type Lane struct {
+	Name      string   `yaml:"name"`
+	Waypoints [][2]int `yaml:"waypoints"`
+}
+
+// GameMap describes impassable terrain and the lanes enemies follow.
+type GameMap struct {
+	Walls [][2]int `yaml:"walls"`
+	Lanes []Lane   `yaml:"lanes"`
+
+	wallSet map[[2]int]bool
+	lanes   map[string]*Lane
+}
+
+// LoadMap parses a YAML file into a GameMap and validates it.
+func LoadMap(path string) (*GameMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMap(data)
+}
+
+// ParseMap parses YAML map data and validates it.
+func ParseMap(data []byte) (*GameMap, error) {
+	var m GameMap
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return nil, err
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// DefaultMap returns an open field with three lanes entering from the right.
+func DefaultMap() *GameMap {
+	m := &GameMap{Lanes: []Lane{
+		{Name: "north", Waypoints: [][2]int{{59, 6}, {40, 6}}},
+		{Name: "center", Waypoints: [][2]int{{59, 16}}},
+		{Name: "south", Waypoints: [][2]int{{59, 26}, {40, 26}}},
+	}}
+	if err := m.validate(); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// validate checks tiles are on the grid, lane names are unique and no
+// waypoint sits on a wall. It also builds the lookup tables.
+func (m *GameMap) validate() error {
+	m.wallSet = make(map[[2]int]bool, len(m.Walls))
+	for _, w := range m.Walls {
+		if !inGrid(w[0], w[1]) {
+			return fmt.Errorf("wall %v outside the grid", w)
+		}
+		m.wallSet[w] = true
+	}
+	m.lanes = make(map[string]*Lane, len(m.Lanes))
+	for i := range m.Lanes {
+		l := &m.Lanes[i]
+		if l.Name == "" {
+			return fmt.Errorf("lane %d has no name", i+1)
+		}
+		if _, dup := m.lanes[l.Name]; dup {
+			return fmt.Errorf("duplicate lane %s", l.Name)
+		}
+		if len(l.Waypoints) == 0 {
+			return fmt.Errorf("lane %s has no waypoints", l.Name)
+		}
+		for _, w := range l.Waypoints {
+			if !inGrid(w[0], w[1]) {
+				return fmt.Errorf("lane %s waypoint %v outside the grid", l.Name, w)
+			}
+			if m.wallSet[w] {
+				return fmt.Errorf("lane %s waypoint %v is a wall", l.Name, w)
+			}
+		}
+		m.lanes[l.Name] = l
+	}
+	return nil
+}
+
+// IsWall reports whether the tile is impassable terrain.
+func (m *GameMap) IsWall(x, y int) bool {
+	return m != nil && m.wallSet[[2]int{x, y}]
+}
+
+// Lane returns the lane with the given name.
+func (m *GameMap) Lane(name string) (*Lane, bool) {
+	if m == nil {
+		return nil, false
+	}
+	l, ok := m.lanes[name]
+	return l, ok
+}
+
+// isWaypoint reports whether any lane passes through the tile.
+func (m *GameMap) isWaypoint(x, y int) bool {
+	if m == nil {
+		return false
+	}
+	for _, l := range m.Lanes {
+		for _, w := range l.Waypoints {
+			if w == [2]int{x, y} {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckLanes reports wave groups that name a lane missing from the map.
+func (s *WaveScript) CheckLanes(m *GameMap) error {
+	for i, w := range s.Waves {
+		for j, g := range w.Groups {
+			if g.Lane == "" {
+				continue
+			}
+			if _, ok := m.Lane(g.Lane); !ok {
+				return fmt.Errorf("wave %d group %d: unknown lane %q", i+1, j+1, g.Lane)
+			}
+		}
+	}
+	return nil
+}
+
+// SetMap replaces the map and makes every mob plan its path again.
+func (g *Game) SetMap(m *GameMap) {
+	if m == nil {
+		return
+	}
+	g.gameMap = m
+	g.invalidatePaths()
+}
+
+// drawMap renders walls and lane waypoints.
+func (g *Game) drawMap(screen *ebiten.Image) {
+	if g.gameMap == nil {
+		return
+	}
+	ts := float32(TileSize)
+	for _, w := range g.gameMap.Walls {
+		x, y := tilePosition(w[0], w[1])
+		vector.DrawFilledRect(screen, float32(x), float32(y), ts, ts, color.RGBA{60, 50, 40, 255}, false)
+	}
+	for _, l := range g.gameMap.Lanes {
+		for _, w := range l.Waypoints {
+			c := tileCenter(w)
+			vector.StrokeCircle(screen, float32(c.X), float32(c.Y), ts/3, 2, color.RGBA{200, 80, 80, 160}, false)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Hero ability words. Each is typed during play to cast the ability.
+const (
+	heroCleave = "cleave" // damages every ground enemy around the hero
+	heroRally  = "rally"  // heals the hero and nearby units
+	heroSmite  = "smite"  // strikes the boss, or the toughest enemy in reach
+)
+
+const (
+	heroKind         = "hero"
+	heroMaxLevel     = 10
+	heroXPPerLevel   = 5    // XP to the next level is this times the level
+	heroBossXP       = 10   // XP for killing a boss, other kills give 1
+	heroHPPerLevel   = 3    // extra hit points per level
+	heroMaxRank      = 5    // highest rank of an ability
+	heroRespawnDelay = 20.0 // seconds before a fallen hero returns to the base
+)
+
+// HeroAbility is one of a hero's typed abilities.
+type HeroAbility struct {
+	Word     string  // heroCleave, heroRally or heroSmite
+	Power    int     // damage or healing at rank 1
+	Radius   float64 // pixels around the hero the ability reaches
+	Cooldown float64 // seconds before the ability can be cast again
+}
+
+// HeroDef describes the hero of a class. The hero is summoned by typing its
+// lowercase name.
+type HeroDef struct {
+	Name      string
+	HP        int
+	Damage    int
+	Speed     float64
+	Abilities [3]HeroAbility
+}
+
+// heroes holds the hero of each class; the "" entry leads runs without a
+// class. The Knight's Roland hits harder up close, the Archer's Robin smites
+// from further away.
+var heroes = map[string]*HeroDef{
+	"": {Name: "Aldric", HP: 18, Damage: 2, Speed: 55, Abilities: [3]HeroAbility{
+		{Word: heroCleave, Power: 2, Radius: 72, Cooldown: 8},
+		{Word: heroRally, Power: 3, Radius: 160, Cooldown: 20},
+		{Word: heroSmite, Power: 8, Radius: 240, Cooldown: 25},
+	}},
+	"Knight": {Name: "Roland", HP: 25, Damage: 3, Speed: 50, Abilities: [3]HeroAbility{
+		{Word: heroCleave, Power: 3, Radius: 80, Cooldown: 8},
+		{Word: heroRally, Power: 4, Radius: 160, Cooldown: 20},
+		{Word: heroSmite, Power: 8, Radius: 200, Cooldown: 25},
+	}},
+	"Archer": {Name: "Robin", HP: 15, Damage: 2, Speed: 65, Abilities: [3]HeroAbility{
+		{Word: heroCleave, Power: 2, Radius: 64, Cooldown: 8},
+		{Word: heroRally, Power: 3, Radius: 160, Cooldown: 20},
+		{Word: heroSmite, Power: 12, Radius: 320, Cooldown: 20},
+	}},
+}
+
+// Hero is the single melee champion of a run. It levels up from its kills,
+// spends a point on one of its abilities every level and returns to the base
+// some time after falling.
+type Hero struct {
+	soldier
+	def      *HeroDef
+	level    int
+	xp       int
+	ranks    [3]int // rank of each ability, starting at 1
+	points   int    // unspent ability points
+	timers   [3]CooldownTimer
+	summoned bool
+	respawn  CooldownTimer
+	phrases  phraseSet // summon word, then the ability words
+}
+
+// NewHero creates an unsummoned level 1 hero from its definition.
+func NewHero(def *HeroDef) *Hero {
+	h := &Hero{
+		soldier: newSoldier(UnitStats{ID: heroKind, HP: def.HP, Damage: def.Damage, Speed: def.Speed}, ImgHero, 0, 0),
+		def:     def,
+		level:   1,
+		ranks:   [3]int{1, 1, 1},
+		respawn: NewCooldownTimer(heroRespawnDelay),
+	}
+	h.phrases = newPhraseSet(strings.ToLower(def.Name))
+	for i, a := range def.Abilities {
+		h.timers[i] = NewCooldownTimer(a.Cooldown)
+		h.timers[i].remaining = 0 // ready when summoned
+		h.phrases.phrases = append(h.phrases.phrases, typedPhrase{text: a.Word})
+	}
+	return h
+}
+
+// melee hits a touching enemy and takes the XP if it dies.
+func (h *Hero) melee(o foe) {
+	if !o.Alive() {
+		return
+	}
+	h.strikeMelee(o)
+	h.credit(o)
+}
+
+// act does nothing: the hero's abilities are typed.
+func (h *Hero) act(m *Military, dt float64) {}
+
+// credit gives the hero XP if the enemy is dead.
+func (h *Hero) credit(o foe) {
+	if o.Alive() {
+		return
+	}
+	if _, boss := o.(*Boss); boss {
+		h.GainXP(heroBossXP)
+		return
+	}
+	h.GainXP(1)
+}
+
+// GainXP adds experience and levels the hero up. Every level raises its
+// health and damage and grants an ability point.
+func (h *Hero) GainXP(xp int) {
+	h.xp += xp
+	for h.level < heroMaxLevel && h.xp >= heroXPPerLevel*h.level {
+		h.xp -= heroXPPerLevel * h.level
+		h.level++
+		h.maxHP += heroHPPerLevel
+		h.hp += heroHPPerLevel
+		h.damage++
+		h.points++
+	}
+}
+
+// Level returns the hero's level.
+func (h *Hero) Level() int { return h.level }
+
+// Name returns the hero's display name.
+func (h *Hero) Name() string { return h.def.Name }
+
+// ability returns the index of the ability cast by word, or -1.
+func (h *Hero) ability(word string) int {
+	for i, a := range h.def.Abilities {
+		if a.Word == word {
+			return i
+		}
+	}
+	return -1
+}
+
+// RankUp spends an ability point on the named ability.
+func (h *Hero) RankUp(word string) bool {
+	i := h.ability(word)
+	if i < 0 || h.points == 0 || h.ranks[i] >= heroMaxRank {
+		return false
+	}
+	h.ranks[i]++
+	h.points--
+	return true
+}
+
+// power returns the ability's damage or healing at its current rank; every
+// rank after the first adds half the base power.
+func (h *Hero) power(i int) int {
+	return h.def.Abilities[i].Power * (h.ranks[i] + 1) / 2
+}
+
+// setProgress restores a saved level, XP and ability ranks. Points not
+// spent on ranks stay available.
+func (h *Hero) setProgress(level, xp int, ranks map[string]int) {
+	h.level, h.xp, h.ranks, h.points = 1, 0, [3]int{1, 1, 1}, 0
+	h.maxHP, h.hp, h.damage = h.def.HP, h.def.HP, h.def.Damage
+	h.GainXP(heroXPPerLevel * (min(level, heroMaxLevel) - 1) * min(level, heroMaxLevel) / 2)
+	h.xp = xp
+	for word, r := range ranks {
+		if i := h.ability(word); i >= 0 {
+			for h.ranks[i] < r && h.RankUp(word) {
+			}
+		}
+	}
+}
+
+// revive puts the hero back at p with full health.
+func (h *Hero) revive(p Point) {
+	h.pos = p
+	h.hp = h.maxHP
+	h.alive = true
+	h.target, h.focus = nil, nil
+	h.rally, h.stance = p, StanceRally
+}
+
+// summonHero brings the hero onto the field at the base.
+func (g *Game) summonHero() {
+	h := g.hero
+	if h == nil || h.summoned || g.base == nil || g.military == nil {
+		return
+	}
+	h.summoned = true
+	h.revive(g.base.pos)
+	g.military.AddUnit(h)
+}
+
+// updateHero ticks the ability cooldowns and brings a fallen hero back to
+// the base once the respawn delay has passed.
+func (g *Game) updateHero(dt float64) {
+	h := g.hero
+	if h == nil || !h.summoned {
+		return
+	}
+	for i := range h.timers {
+		h.timers[i].Tick(dt)
+	}
+	if h.Alive() {
+		return
+	}
+	if h.respawn.Tick(dt) {
+		h.respawn.Reset()
+		h.summoned = false
+		g.summonHero()
+	}
+}
+
+// typeHero feeds typed letters to the hero's name, which summons it, and to
+// its ability words, which cast abilities that are off cooldown. It returns
+// the letters left for the queue.
+func (g *Game) typeHero(typed []rune) []rune {
+	h := g.hero
+	if h == nil {
+		return typed
+	}
+	active := func(i int) bool {
+		if i == 0 {
+			return !h.summoned
+		}
+		return h.summoned && h.Alive() && h.timers[i-1].Ready()
+	}
+	return h.phrases.take(typed, active, func(i int) {
+		if i == 0 {
+			g.summonHero()
+		} else {
+			g.castHeroAbility(i - 1)
+		}
+	})
+}
+
+// castHeroAbility triggers a hero ability and starts its cooldown.
+func (g *Game) castHeroAbility(i int) {
+	h := g.hero
+	a := h.def.Abilities[i]
+	power := h.power(i)
+	within := func(x, y float64) bool { return math.Hypot(x-h.pos.X, y-h.pos.Y) <= a.Radius }
+	switch a.Word {
+	case heroCleave:
+		for _, e := range g.mobs {
+			if x, y := e.Position(); e.Alive() && e.Layer() == LayerGround && within(x, y) {
+				e.Damage(power)
+				h.credit(e)
+			}
+		}
+	case heroRally:
+		h.heal(power)
+		for _, u := range g.military.Units() {
+			if x, y := u.Position(); within(x, y) {
+				u.body().heal(power)
+			}
+		}
+	case heroSmite:
+		var target Enemy
+		if b := g.activeBoss(); b != nil && within(b.Position()) {
+			target = b
+		} else {
+			best := 0
+			for _, e := range g.mobs {
+				if x, y := e.Position(); e.Alive() && within(x, y) && enemyHealth(e) > best {
+					target, best = e, enemyHealth(e)
+				}
+			}
+		}
+		if target == nil {
+			return // nothing in reach, keep the cooldown
+		}
+		target.Damage(power)
+		h.credit(target)
+	}
+	h.timers[i].Reset()
+}
+
+// enemyHealth returns an enemy's current health, or 1 when it has no
+// health to report.
+func enemyHealth(e Enemy) int {
+	if hp, ok := e.(interface{ Health() int }); ok {
+		return hp.Health()
+	}
+	return 1
+}
+
+// heroLine is the HUD line for the hero: the word that summons it, the time
+// until it returns, or its level and ability cooldowns.
+func (g *Game) heroLine() string {
+	h := g.hero
+	if h == nil {
+		return ""
+	}
+	switch {
+	case !h.summoned:
+		return fmt.Sprintf("Hero: type %s to summon %s", h.phrases.text(0), h.def.Name)
+	case !h.Alive():
+		return fmt.Sprintf("%s returns in %.0fs", h.def.Name, math.Ceil(h.respawn.Remaining()))
+	}
+	parts := []string{fmt.Sprintf("%s Lv%d %d/%d HP", h.def.Name, h.level, h.hp, h.maxHP)}
+	for i, a := range h.def.Abilities {
+		if h.timers[i].Ready() {
+			parts = append(parts, fmt.Sprintf("%s %d", a.Word, h.ranks[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %.0fs", a.Word, math.Ceil(h.timers[i].Remaining())))
+		}
+	}
+	if h.points > 0 {
+		parts = append(parts, fmt.Sprintf("%d pts (:hero <ability>)", h.points))
+	}
+	return strings.Join(parts, " | ")
+}
@@ -0,0 +1,95 @@
+//go:build test
+
+package game
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHeroTiedToClass(t *testing.T) {
+	g := NewGame()
+	g.SetClass("Knight")
+	if g.hero.Name() != "Roland" {
+		t.Errorf("Knight hero should be Roland, got %s", g.hero.Name())
+	}
+	if g = NewGame(); g.hero.Name() != "Aldric" {
+		t.Errorf("runs without a class should lead with Aldric, got %s", g.hero.Name())
+	}
+}
+
+func TestHeroLevelsAndRanks(t *testing.T) {
+	h := NewHero(heroes[""])
+	if h.RankUp(heroCleave) {
+		t.Fatalf("a level 1 hero has no ability points")
+	}
+	h.GainXP(heroXPPerLevel*1 + heroXPPerLevel*2)
+	if h.Level() != 3 || h.points != 2 || h.maxHP != heroes[""].HP+2*heroHPPerLevel {
+		t.Fatalf("expected level 3 with 2 points, got level %d, %d points, %d hp", h.Level(), h.points, h.maxHP)
+	}
+	if !h.RankUp(heroSmite) || h.power(2) != heroes[""].Abilities[2].Power*3/2 {
+		t.Errorf("rank 2 smite should deal 1.5x power, got %d", h.power(2))
+	}
+}
+
+func TestHeroCleaveGivesXP(t *testing.T) {
+	g := NewGame()
+	g.typeHero([]rune(g.hero.phrases.text(0)))
+	o := NewOrcGrunt(g.base.pos.X+20, g.base.pos.Y)
+	o.hp = 1
+	g.mobs = append(g.mobs, o)
+	g.typeHero([]rune(heroCleave))
+	if o.Alive() || g.hero.xp != 1 {
+		t.Errorf("cleave should kill the grunt and give 1 XP, alive %v xp %d", o.Alive(), g.hero.xp)
+	}
+	if g.hero.timers[0].Ready() {
+		t.Errorf("cleave should go on cooldown")
+	}
+	if line := g.heroLine(); line == "" {
+		t.Errorf("the HUD should show the hero")
+	}
+}
+
+func TestHeroRespawnsAtBase(t *testing.T) {
+	g := NewGame()
+	g.typeHero([]rune(g.hero.phrases.text(0)))
+	g.hero.Damage(1000)
+	g.military.Update(0.1, nil)
+	if g.military.Count() != 0 {
+		t.Fatalf("the fallen hero should leave the army")
+	}
+	g.updateHero(heroRespawnDelay)
+	if !g.hero.Alive() || g.military.Count() != 1 || g.hero.pos != g.base.pos {
+		t.Errorf("the hero should return to the base after the respawn delay")
+	}
+}
+
+func TestSaveKeepsHero(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.json")
+	g := NewGame()
+	g.SetClass("Archer")
+	g.startRun()
+	g.typeHero([]rune(g.hero.phrases.text(0)))
+	g.hero.GainXP(heroXPPerLevel + 2)
+	g.hero.RankUp(heroSmite)
+	g.saveGame(path)
+	l := NewGame()
+	if err := l.loadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	h := l.hero
+	if h.Name() != "Robin" || h.Level() != 2 || h.xp != 2 || h.ranks[2] != 2 || !h.summoned {
+		t.Errorf("loaded %s level %d xp %d ranks %v summoned %v", h.Name(), h.Level(), h.xp, h.ranks, h.summoned)
+	}
+}
+
+func TestHeroWordsKeepLettersFromQueue(t *testing.T) {
+	g := NewGame()
+	name := g.hero.phrases.text(0)
+	if rest := g.typeHero([]rune("f" + name)); string(rest) != "f" {
+		t.Errorf("the summon word should not reach the queue, left %q", string(rest))
+	}
+	if rest := g.typeHero([]rune(heroRally + "j")); string(rest) != "j" {
+		t.Errorf("ability words should not reach the queue, left %q", string(rest))
+	}
+}
@@ -74,7 +74,8 @@ func (h *HUD) drawResourceIcons(screen *ebiten.Image) {
 		{"W", h.game.resources.WoodAmount(), color.RGBA{139, 69, 19, 255}},
 		{"S", h.game.resources.StoneAmount(), color.RGBA{128, 128, 128, 255}},
 		{"I", h.game.resources.IronAmount(), color.RGBA{169, 169, 169, 255}},
-		{"M", 0, color.RGBA{75, 0, 130, 255}},
+		{"F", h.game.resources.FoodAmount(), color.RGBA{110, 190, 70, 255}},
+		{"M", h.game.resources.ManaAmount(), color.RGBA{75, 0, 130, 255}},
 	}
 
 	size := 20.0
@@ -97,6 +98,52 @@ func (h *HUD) drawResourceIcons(screen *ebiten.Image) {
 
 		x += size + float64(len(numStr))*13.0 + 16
 	}
+
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(x, y+14)
+	opts.ColorScale.ScaleWithColor(color.White)
+	text.Draw(screen, h.game.supplyLine(), BoldFont, opts)
+}
+
+// drawModeStatus shows the wave counter below the resources, with the
+// Endless timer and score.
+func (h *HUD) drawModeStatus(screen *ebiten.Image) {
+	drawMenu(screen, []string{h.game.modeStatus()}, 10, 40)
+}
+
+// drawClassAbility shows the class ability phrase or its cooldown.
+func (h *HUD) drawClassAbility(screen *ebiten.Image) {
+	if line := h.game.classLine(); line != "" {
+		drawMenu(screen, []string{line}, 10, 130)
+	}
+}
+
+// drawBarracksUnit shows which unit the next Barracks word trains.
+func (h *HUD) drawBarracksUnit(screen *ebiten.Image) {
+	if line := h.game.barracksLine(); line != "" {
+		drawMenu(screen, []string{line}, 10, 160)
+	}
+}
+
+// drawSanctum shows the spell the Sanctum is preparing.
+func (h *HUD) drawSanctum(screen *ebiten.Image) {
+	if line := h.game.sanctumLine(); line != "" {
+		drawMenu(screen, []string{line}, 10, 250)
+	}
+}
+
+// drawArmy shows the army's current order or the shout being typed.
+func (h *HUD) drawArmy(screen *ebiten.Image) {
+	if line := h.game.armyLine(); line != "" {
+		drawMenu(screen, []string{line}, 10, 190)
+	}
+}
+
+// drawHero shows the hero's level and ability cooldowns.
+func (h *HUD) drawHero(screen *ebiten.Image) {
+	if line := h.game.heroLine(); line != "" {
+		drawMenu(screen, []string{line}, 10, 220)
+	}
 }
 
 // drawQueue renders the global typing queue at the top center of the screen.
@@ -120,6 +167,10 @@ func (h *HUD) drawQueue(screen *ebiten.Image) {
 	y := h.game.wordProcessY
 
 	for i, w := range words {
+		if w.Family == magicFamily {
+			// spell words are boxed so they stand out from production words
+			vector.StrokeRect(screen, float32(x-4), float32(y-2), float32(len(w.Text))*13+8, 28, 2, FamilyColor(w.Family), false)
+		}
 		opts := &text.DrawOptions{}
 		opts.GeoM.Translate(x, y)
 		if i == 0 {
@@ -170,6 +221,31 @@ func (h *HUD) drawTowerSelectionOverlay(screen *ebiten.Image) {
 		opts.ColorScale.ScaleWithColor(color.White)
 		text.Draw(screen, label, BoldFont, opts)
 	}
+	hint := "label: upgrade   $label: sell   >label: move"
+	if h.game.shopOpen {
+		hint += "   <: undo build"
+	}
+	switch h.game.towerAction {
+	case towerActionSell:
+		hint = "-- SELL: type a tower label --"
+	case towerActionMove:
+		hint = "-- MOVE: type a tower label --"
+	}
+	drawMenu(screen, []string{hint}, 700, 1000)
+}
+
+// drawTowerMovePrompt shows the relocation steps while a tower is being moved.
+func (h *HUD) drawTowerMovePrompt(screen *ebiten.Image) {
+	m := h.game.moving
+	if m == nil {
+		return
+	}
+	title := fmt.Sprintf("-- MOVE TOWER (%dG) --", h.game.towerMoveCost())
+	lines := []string{title, "hjkl: choose tile, Enter: confirm, Backspace: cancel"}
+	if m.typing {
+		lines = []string{title, fmt.Sprintf("Type: %s", m.word), "> " + m.word[:m.typed]}
+	}
+	drawMenu(screen, lines, 760, 300)
 }
 
 // drawTechMenu renders the tech purchase overlay when active.
@@ -184,7 +260,12 @@ func (h *HUD) drawTechMenu(screen *ebiten.Image) {
 		for _, r := range n.Letters {
 			letters.WriteRune(r)
 		}
-		line := fmt.Sprintf("%s [%s] - %s", n.Name, letters.String(), n.Achievement)
+		line := fmt.Sprintf("%s [%s] - %s, %s", n.Name, letters.String(), n.Achievement, h.game.techCost(h.game.techTree.stageOf(n.Name)))
+		if h.game.sanctum != nil {
+			for _, s := range h.game.sanctum.Book().Teaches(n.Name) {
+				line += ", " + s
+			}
+		}
 		prefix := "  "
 		if i == h.game.techCursor {
 			prefix = "> "
@@ -194,12 +275,85 @@ func (h *HUD) drawTechMenu(screen *ebiten.Image) {
 	drawMenu(screen, lines, 760, 300)
 }
 
+// buildMenuTowers names the towers of the build menu in key order.
+var buildMenuTowers = []struct {
+	name string
+	tt   TowerType
+}{{"Basic", TowerBasic}, {"Sniper", TowerSniper}, {"Rapid", TowerRapid}, {"Banner", TowerBanner}, {"Ballista", TowerBallista}}
+
+// drawBuildMenu lists the towers and the house with their prices.
+func (h *HUD) drawBuildMenu(screen *ebiten.Image) {
+	g := h.game
+	if !g.buildMenuOpen {
+		return
+	}
+	lines := []string{"-- BUILD --"}
+	for i, b := range buildMenuTowers {
+		lines = append(lines, g.costLine(i == g.buildCursor, fmt.Sprintf("%d %s", i+1, b.name), g.towerCost(b.tt)))
+	}
+	lines = append(lines, g.costLine(g.buildCursor == len(buildMenuTowers), fmt.Sprintf("%d House", len(buildMenuTowers)+1), g.costs().House))
+	drawMenu(screen, lines, 760, 300)
+}
+
+// drawUpgradeMenu lists the selected tower's upgrades with their prices. In
+// the shop it also offers the Farmer and Barracks letter unlocks, naming the
+// units the next Barracks stage trains.
+func (h *HUD) drawUpgradeMenu(screen *ebiten.Image) {
+	g := h.game
+	if len(g.towers) == 0 {
+		return
+	}
+	switch {
+	case g.upgradeMenuOpen:
+		lines := append([]string{"-- UPGRADE --"}, g.upgradeMenuLines(len(towerUpgrades), g.upgradeCursor)...)
+		drawMenu(screen, append(lines, cursorLine(g.upgradeCursor == len(towerUpgrades), "Done")), 760, 300)
+	case g.shopOpen && g.farmer != nil && g.barracks != nil:
+		lines := append([]string{"-- SHOP --"}, g.upgradeMenuLines(5, g.shopCursor)...)
+		for i, b := range []struct {
+			name  string
+			cost  int
+			units []UnitStats
+		}{{"Farmer letters", g.farmer.NextUnlockCost(), nil}, {"Barracks letters", g.barracks.NextUnlockCost(), g.barracks.NextUnits()}} {
+			label := fmt.Sprintf("%d %s", i+6, b.name)
+			for _, u := range b.units {
+				label += ", trains " + u.Name
+			}
+			if b.cost < 0 {
+				lines = append(lines, cursorLine(g.shopCursor == i+5, label+" - done"))
+				continue
+			}
+			lines = append(lines, g.costLine(g.shopCursor == i+5, label, Cost{Kings: b.cost}))
+		}
+		drawMenu(screen, append(lines, cursorLine(g.shopCursor == 7, "Next wave")), 760, 300)
+	}
+}
+
+// cursorLine prefixes a menu entry with the cursor marker when selected.
+func cursorLine(selected bool, label string) string {
+	if selected {
+		return "> " + label
+	}
+	return "  " + label
+}
+
+// drawWaveSummary shows the King's Points earned in the last wave while the
+// shop is open, or briefly after an Endless wave.
+func (h *HUD) drawWaveSummary(screen *ebiten.Image) {
+	g := h.game
+	if !g.shopOpen && g.summaryTimer <= 0 {
+		return
+	}
+	if lines := g.waveSummaryLines(); lines != nil {
+		drawMenu(screen, lines, 40, 620)
+	}
+}
+
 // drawSkillMenu renders the global skill tree overlay when active.
 func (h *HUD) drawSkillMenu(screen *ebiten.Image) {
 	if !h.game.skillMenuOpen {
 		return
 	}
-	categories := []string{"Offense", "Defense", "Typing", "Automation", "Utility"}
+	categories := []string{"Offense", "Defense", "Typing", "Automation", "Utility", "Class"}
 	cat := categories[h.game.skillCategory]
 	nodes := h.game.skillNodesByCategory(SkillCategory(h.game.skillCategory))
 	lines := []string{"-- SKILLS --", "Category: " + cat}
@@ -315,6 +469,7 @@ func (h *HUD) drawStatsPanel(screen *ebiten.Image) {
 	lines := []string{"-- STATS --"}
 	lines = append(lines, fmt.Sprintf("WPM: %.1f", h.game.typing.RollingWPM()))
 	lines = append(lines, fmt.Sprintf("Accuracy: %.0f%%", h.game.typing.Accuracy()*100))
+	lines = append(lines, "Difficulty: "+h.game.Difficulty())
 	lines = append(lines, "")
 	hist := h.game.WordHistory()
 	start := len(hist) - 5
@@ -332,14 +487,150 @@ func (h *HUD) drawStatsPanel(screen *ebiten.Image) {
 	drawMenu(screen, lines, 720, 480)
 }
 
+// towerStatLines formats each derived stat of t followed by the sources that
+// changed it, e.g. "Damage 4: base 1, type +2, shop +1".
+func towerStatLines(t *Tower) []string {
+	layers := t.StatBreakdown()
+	if len(layers) == 0 {
+		return nil
+	}
+	num := func(v float64) string {
+		if v == math.Trunc(v) {
+			return fmt.Sprintf("%.0f", v)
+		}
+		return fmt.Sprintf("%.2f", v)
+	}
+	line := func(name, total string, get func(TowerStats) float64) string {
+		out := fmt.Sprintf("%s %s: base %s", name, total, num(get(layers[0].Stats)))
+		prev := get(layers[0].Stats)
+		for _, l := range layers[1:] {
+			v := get(l.Stats)
+			if d := v - prev; math.Abs(d) > 1e-9 {
+				sign := "+"
+				if d < 0 {
+					sign = ""
+				}
+				out += fmt.Sprintf(", %s %s%s", l.Source, sign, num(d))
+			}
+			prev = v
+		}
+		return out
+	}
+	return []string{
+		line("Damage", fmt.Sprint(t.damage), func(s TowerStats) float64 { return s.Damage }),
+		line("Range", num(t.rangeDst), func(s TowerStats) float64 { return s.Range }),
+		line("Rate", num(t.rate)+"s", func(s TowerStats) float64 { return s.Rate }),
+		line("Ammo", fmt.Sprint(t.ammoCapacity), func(s TowerStats) float64 { return float64(s.AmmoCapacity) }),
+		line("Foresight", fmt.Sprint(t.foresight), func(s TowerStats) float64 { return float64(s.Foresight) }),
+	}
+}
+
+// drawTowerStats shows where the selected tower's numbers come from while its
+// upgrades are on offer.
+func (h *HUD) drawTowerStats(screen *ebiten.Image) {
+	g := h.game
+	if (!g.upgradeMenuOpen && !g.shopOpen) || len(g.towers) == 0 {
+		return
+	}
+	idx := g.selectedTower
+	if idx < 0 || idx >= len(g.towers) {
+		return
+	}
+	lines := append([]string{"-- TOWER STATS --"}, towerStatLines(g.towers[idx])...)
+	drawMenu(screen, lines, 40, 300)
+}
+
+// bossLines describes a boss's health, phase and any phrase waiting to be
+// typed.
+func bossLines(b *Boss) []string {
+	idx, phase := b.Phase()
+	lines := []string{
+		b.def.Name,
+		fmt.Sprintf("HP %s %d/%d", progressBar(b.HealthFraction(), 20), b.health, b.maxHP),
+		fmt.Sprintf("Phase %d/%d: %s", idx+1, len(b.def.Phases), phase.Name),
+	}
+	if b.weak.active() {
+		lines = append(lines, fmt.Sprintf("Weak point: %s", b.weak.text[b.weak.typed:]))
+	}
+	if b.silence != "" {
+		lines = append(lines, fmt.Sprintf("%s silenced - type %s", b.silence, b.counter.text[b.counter.typed:]))
+	}
+	return lines
+}
+
+// drawBossBar shows the active boss's health and phase.
+func (h *HUD) drawBossBar(screen *ebiten.Image) {
+	if b := h.game.activeBoss(); b != nil {
+		drawMenu(screen, bossLines(b), 760, 60)
+	}
+}
+
+// drawWavePreview lists the coming waves while the shop is open or an
+// Endless wave is about to start.
+func (h *HUD) drawWavePreview(screen *ebiten.Image) {
+	if !h.game.showWavePreview() {
+		return
+	}
+	drawMenu(screen, h.game.scoutingLines(), 1400, 300)
+}
+
+// drawSpawnIndicators marks the right-edge tiles where mobs are about to
+// appear, growing brighter as the spawn approaches.
+func (h *HUD) drawSpawnIndicators(screen *ebiten.Image) {
+	type marker struct {
+		mob   string
+		count int
+		eta   float64
+	}
+	var order [][2]int
+	markers := map[[2]int]*marker{}
+	for _, u := range h.game.upcomingSpawns() {
+		if !u.spawn.hasEntry {
+			continue
+		}
+		m, ok := markers[u.spawn.entry]
+		if !ok {
+			m = &marker{mob: u.spawn.group.Mob, eta: u.eta}
+			markers[u.spawn.entry] = m
+			order = append(order, u.spawn.entry)
+		}
+		m.count++
+	}
+	for _, tile := range order {
+		m := markers[tile]
+		c := tileCenter(tile)
+		alpha := uint8(120 + 135*math.Max(0, math.Min(1, 1-m.eta/spawnWarning)))
+		ts := float32(TileSize)
+		vector.StrokeRect(screen, float32(c.X)-ts/2, float32(c.Y)-ts/2, ts, ts, 3, color.RGBA{255, 60, 0, alpha}, false)
+		opts := &text.DrawOptions{}
+		opts.GeoM.Translate(c.X-float64(TileSize)*5, c.Y-10)
+		opts.ColorScale.ScaleWithColor(color.RGBA{255, 60, 0, 255})
+		text.Draw(screen, spawnIndicatorLabel(m.mob, m.count, m.eta), BoldFont, opts)
+	}
+}
+
 // Draw renders the HUD elements on screen
 func (h *HUD) Draw(screen *ebiten.Image) {
 	h.drawResourceIcons(screen)
+	h.drawModeStatus(screen)
+	h.drawClassAbility(screen)
+	h.drawBarracksUnit(screen)
+	h.drawArmy(screen)
+	h.drawHero(screen)
+	h.drawSanctum(screen)
 	h.drawWordStats(screen)
 	h.drawQueue(screen)
 	h.drawTowerSelectionOverlay(screen)
+	h.drawTowerMovePrompt(screen)
 	h.drawTechMenu(screen)
+	h.drawBuildMenu(screen)
+	h.drawUpgradeMenu(screen)
 	h.drawSkillMenu(screen)
 	h.drawSlotMenu(screen)
 	h.drawStatsPanel(screen)
+	h.drawTowerStats(screen)
+	h.drawWaveSummary(screen)
+	h.drawBossBar(screen)
+	h.drawWavePreview(screen)
+	h.drawSpawnIndicators(screen)
 }
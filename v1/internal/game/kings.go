@@ -0,0 +1,186 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// kingsSummaryTime is how long the wave summary stays up in Endless, which
+// has no shop between waves.
+const kingsSummaryTime = 8.0
+
+// KingsMilestone awards Points once the wave reaches At: a percentage for
+// accuracy, words per minute for WPM.
+type KingsMilestone struct {
+	At     float64 `json:"at"`
+	Points int     `json:"points"`
+}
+
+// KingsRules sets how King's Points are earned. Waves are judged on the
+// conveyor words completed during them; milestones stack, so a wave at 99%
+// accuracy earns both a 90 and a 98 milestone.
+type KingsRules struct {
+	WaveCleared  int              `json:"wave_cleared"`  // points for every wave cleared
+	PerfectWords int              `json:"perfect_words"` // perfect words per point, 0 for none
+	BossKill     int              `json:"boss_kill"`     // points for every boss the player kills
+	Accuracy     []KingsMilestone `json:"accuracy"`
+	WPM          []KingsMilestone `json:"wpm"`
+}
+
+// DefaultKingsRules pays a point a wave plus bonuses for clean, fast typing
+// and for bosses.
+var DefaultKingsRules = KingsRules{
+	WaveCleared:  1,
+	PerfectWords: 5,
+	BossKill:     3,
+	Accuracy:     []KingsMilestone{{At: 90, Points: 1}, {At: 98, Points: 1}},
+	WPM:          []KingsMilestone{{At: 30, Points: 1}, {At: 60, Points: 1}},
+}
+
+// validateKingsRules rejects negative rewards and accuracy milestones beyond
+// 100%.
+func validateKingsRules(r KingsRules) error {
+	if r.WaveCleared < 0 || r.PerfectWords < 0 || r.BossKill < 0 {
+		return fmt.Errorf("kings_points: negative value")
+	}
+	for _, m := range append(append([]KingsMilestone(nil), r.Accuracy...), r.WPM...) {
+		if m.At < 0 || m.Points < 0 {
+			return fmt.Errorf("kings_points: milestone %+v is negative", m)
+		}
+	}
+	for _, m := range r.Accuracy {
+		if m.At > 100 {
+			return fmt.Errorf("kings_points: accuracy milestone %.0f is over 100", m.At)
+		}
+	}
+	return nil
+}
+
+// milestonePoints sums the points of every milestone reached by v.
+func milestonePoints(ms []KingsMilestone, v float64) int {
+	pts := 0
+	for _, m := range ms {
+		if v >= m.At {
+			pts += m.Points
+		}
+	}
+	return pts
+}
+
+// nextMilestone returns the lowest milestone above v, if any.
+func nextMilestone(ms []KingsMilestone, v float64) (KingsMilestone, bool) {
+	sorted := append([]KingsMilestone(nil), ms...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].At < sorted[j].At })
+	for _, m := range sorted {
+		if v < m.At {
+			return m, true
+		}
+	}
+	return KingsMilestone{}, false
+}
+
+// kingsReward is the King's Points earned in one wave, broken down for the
+// wave summary.
+type kingsReward struct {
+	Wave     int
+	Cleared  int
+	Accuracy int
+	WPM      int
+	Perfect  int
+	Bosses   int
+
+	accuracy float64 // wave accuracy in percent, -1 when nothing was typed
+	wpm      float64
+	perfect  int // words typed without a mistake
+}
+
+// Total returns every point earned in the wave.
+func (r kingsReward) Total() int { return r.Cleared + r.Accuracy + r.WPM + r.Perfect + r.Bosses }
+
+// waveWPM returns the words per minute of letters typed over the time spent
+// on the wave's words, using a 5 chars per word estimate.
+func waveWPM(letters int, spent time.Duration) float64 {
+	if spent <= 0 {
+		return 0
+	}
+	return float64(letters) / 5 / spent.Minutes()
+}
+
+// kingsRules returns the configured earning rules.
+func (g *Game) kingsRules() KingsRules {
+	if g.cfg == nil {
+		return DefaultKingsRules
+	}
+	return g.cfg.KingsPoints
+}
+
+// creditBossKill pays the boss reward at once and remembers it for the wave
+// summary.
+func (g *Game) creditBossKill() {
+	pts := g.kingsRules().BossKill
+	g.bossKings += pts
+	g.resources.AddKingsPoints(pts)
+}
+
+// awardWaveKings pays the King's Points for the wave just cleared and keeps
+// the breakdown for the wave summary. Boss points were paid when the boss
+// fell.
+func (g *Game) awardWaveKings() {
+	rules := g.kingsRules()
+	r := kingsReward{Wave: g.currentWave, Cleared: rules.WaveCleared, Bosses: g.bossKings, accuracy: -1}
+	correct, incorrect := 0, 0
+	var spent time.Duration
+	for _, w := range g.wordHistory[min(g.waveWords, len(g.wordHistory)):] {
+		correct += w.Correct
+		incorrect += w.Incorrect
+		spent += w.Duration
+		if w.Incorrect == 0 && w.Correct > 0 {
+			r.perfect++
+		}
+	}
+	if correct+incorrect > 0 {
+		r.accuracy = 100 * float64(correct) / float64(correct+incorrect)
+		r.Accuracy = milestonePoints(rules.Accuracy, r.accuracy)
+	}
+	r.wpm = waveWPM(correct+incorrect, spent) + float64(g.wpmBonus)
+	r.WPM = milestonePoints(rules.WPM, r.wpm)
+	if rules.PerfectWords > 0 {
+		r.Perfect = r.perfect / rules.PerfectWords
+	}
+	g.resources.AddKingsPoints(r.Total() - r.Bosses)
+	g.waveReward = &r
+	g.waveWords = len(g.wordHistory)
+	g.bossKings = 0
+	g.summaryTimer = kingsSummaryTime
+}
+
+// waveSummaryLines describes the King's Points earned in the last wave and
+// how to earn more.
+func (g *Game) waveSummaryLines() []string {
+	r := g.waveReward
+	if r == nil {
+		return nil
+	}
+	rules := g.kingsRules()
+	lines := []string{fmt.Sprintf("-- WAVE %d CLEARED --", r.Wave), fmt.Sprintf("Wave cleared: +%d KP", r.Cleared)}
+	if r.accuracy >= 0 {
+		line := fmt.Sprintf("Accuracy %.0f%%: +%d KP", r.accuracy, r.Accuracy)
+		if m, ok := nextMilestone(rules.Accuracy, r.accuracy); ok {
+			line += fmt.Sprintf(" (%.0f%% for +%d)", m.At, m.Points)
+		}
+		lines = append(lines, line)
+	}
+	line := fmt.Sprintf("WPM %.0f: +%d KP", r.wpm, r.WPM)
+	if m, ok := nextMilestone(rules.WPM, r.wpm); ok {
+		line += fmt.Sprintf(" (%.0f for +%d)", m.At, m.Points)
+	}
+	lines = append(lines, line)
+	if rules.PerfectWords > 0 {
+		lines = append(lines, fmt.Sprintf("Perfect words %d: +%d KP", r.perfect, r.Perfect))
+	}
+	if r.Bosses > 0 {
+		lines = append(lines, fmt.Sprintf("Bosses: +%d KP", r.Bosses))
+	}
+	return append(lines, fmt.Sprintf("Total: +%d KP (%d KP)", r.Total(), g.resources.KingsAmount()))
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaveKingsReward(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, typing: NewTypingStats(), currentWave: 2, wpmBonus: 45}
+	for i := 0; i < 5; i++ {
+		g.wordHistory = append(g.wordHistory, WordStat{Text: "fjfj", Correct: 4})
+	}
+	g.wordHistory = append(g.wordHistory, WordStat{Text: "jffjj", Correct: 5, Incorrect: 1})
+	g.creditBossKill()
+	if g.resources.KingsAmount() != 3 {
+		t.Fatalf("boss kill should pay at once, have %d KP", g.resources.KingsAmount())
+	}
+	g.awardWaveKings()
+	r := g.waveReward
+	if r.Cleared != 1 || r.Accuracy != 1 || r.WPM != 1 || r.Perfect != 1 || r.Bosses != 3 {
+		t.Fatalf("unexpected reward %+v", *r)
+	}
+	if g.resources.KingsAmount() != 7 || r.Total() != 7 {
+		t.Errorf("expected 7 KP, have %d (reward %d)", g.resources.KingsAmount(), r.Total())
+	}
+	lines := g.waveSummaryLines()
+	if lines[0] != "-- WAVE 2 CLEARED --" || lines[len(lines)-1] != "Total: +7 KP (7 KP)" {
+		t.Errorf("unexpected summary %q", lines)
+	}
+	if !strings.Contains(strings.Join(lines, "\n"), "Accuracy 96%: +1 KP (98% for +1)") {
+		t.Errorf("summary should show the next accuracy milestone: %q", lines)
+	}
+
+	g.wpmBonus = 0
+	g.awardWaveKings()
+	if r := g.waveReward; r.Total() != 1 || r.accuracy >= 0 || r.Bosses != 0 {
+		t.Errorf("a quiet wave should only pay for clearing it, got %+v", *r)
+	}
+}
+
+func TestLoadConfigKingsRules(t *testing.T) {
+	tmp, err := os.CreateTemp("", "cfg*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+	tmp.WriteString(`{"kings_points":{"wave_cleared":2,"accuracy":[{"at":150,"points":1}]}}`)
+	tmp.Close()
+	if _, err := LoadConfig(tmp.Name()); err == nil {
+		t.Errorf("accuracy milestones over 100%% should be rejected")
+	}
+	os.WriteFile(tmp.Name(), []byte(`{"kings_points":{"wave_cleared":2,"accuracy":[]}}`), 0644)
+	cfg, err := LoadConfig(tmp.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := cfg.KingsPoints
+	if k.WaveCleared != 2 || len(k.Accuracy) != 0 || len(k.WPM) != len(DefaultKingsRules.WPM) || k.BossKill != DefaultKingsRules.BossKill {
+		t.Errorf("unexpected rules %+v", k)
+	}
+	if len(DefaultConfig.KingsPoints.Accuracy) != 2 {
+		t.Errorf("loading must not change the defaults")
+	}
+}
+
+func TestWaveKingsJudgesWPMPerWave(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, typing: NewTypingStats(), currentWave: 1}
+	// 50 letters in 10 seconds is 60 WPM
+	g.wordHistory = append(g.wordHistory, WordStat{Text: "fjfjf", Correct: 50, Duration: 10 * time.Second})
+	g.awardWaveKings()
+	if g.waveReward.WPM != 2 {
+		t.Fatalf("a 60 WPM wave should reach both milestones, got %+v", *g.waveReward)
+	}
+	// 5 letters in 60 seconds is 1 WPM
+	g.wordHistory = append(g.wordHistory, WordStat{Text: "fjfjf", Correct: 5, Duration: time.Minute})
+	g.awardWaveKings()
+	if g.waveReward.WPM != 0 {
+		t.Errorf("a slow wave must not earn WPM points from an earlier one, got %+v", *g.waveReward)
+	}
+}
+
+func TestWinningPaysLastWave(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, typing: NewTypingStats(), currentWave: classicWaves}
+	g.winRun()
+	if g.waveReward == nil || g.waveReward.Wave != classicWaves || g.resources.KingsAmount() != DefaultKingsRules.WaveCleared {
+		t.Errorf("the final wave should be paid, have %d KP", g.resources.KingsAmount())
+	}
+}
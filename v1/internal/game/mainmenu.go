@@ -56,6 +56,7 @@ func (m *MainMenu) Update(g *Game, dt float64) error {
 		case 0:
 			g.phase = PhasePreGame
 			g.preGame = NewPreGame()
+			g.preGame.SetDifficulties(g.cfg.Difficulties)
 			if g.sound != nil {
 				g.sound.StopMusic()
 				g.sound.PlayBeep()
@@ -1,19 +1,143 @@
 package game
 
-// Military manages all player-controlled units such as Footmen.
+import "math"
+
+// Military manages all player-controlled units such as Footmen, Archers,
+// Knights and Healers.
 type Military struct {
-	units []*Footman
+	units    []Unit
+	base     *Base  // healed by Healers standing near it
+	rally    Point  // shared rally point for new units once ordered
+	stance   Stance // shared stance for new units once ordered
+	hasOrder bool   // whether a rally point has been ordered
+	squad    string // unit kind that receives orders, "" for every unit
+	morale   float64
 }
 
 // NewMilitary creates an empty Military manager.
 func NewMilitary() *Military {
-	return &Military{units: make([]*Footman, 0)}
+	return &Military{units: make([]Unit, 0), morale: 1}
+}
+
+// AddUnit registers a new unit with the military system. New units follow
+// the last order given to the whole army and join its rally point once one
+// has been ordered.
+func (m *Military) AddUnit(u Unit) {
+	if u != nil {
+		p, _ := u.Rally()
+		if m.hasOrder {
+			p = m.rally
+		}
+		u.SetRally(p, m.stance)
+		u.body().morale = m.morale
+		m.units = append(m.units, u)
+	}
+}
+
+// SetMorale sets the damage multiplier of every current and future unit.
+func (m *Military) SetMorale(morale float64) {
+	m.morale = morale
+	for _, u := range m.units {
+		u.body().morale = morale
+	}
+}
+
+// Supply returns the supply taken by the units.
+func (m *Military) Supply() int {
+	n := 0
+	for _, u := range m.units {
+		if u.Alive() {
+			n += u.body().supply
+		}
+	}
+	return n
+}
+
+// SetBase sets the base that Healers restore.
+func (m *Military) SetBase(b *Base) { m.base = b }
+
+// SetSquad selects the unit kind that receives later orders. An empty kind
+// or "all" selects every unit.
+func (m *Military) SetSquad(kind string) {
+	if kind == "all" {
+		kind = ""
+	}
+	m.squad = kind
+}
+
+// Squad returns the unit kind receiving orders, "" for every unit.
+func (m *Military) Squad() string { return m.squad }
+
+// Order returns the last order given to the whole army.
+func (m *Military) Order() Stance { return m.stance }
+
+// selected returns the units that receive orders.
+func (m *Military) selected() []Unit {
+	if m.squad == "" {
+		return m.units
+	}
+	var out []Unit
+	for _, u := range m.units {
+		if u.Kind() == m.squad {
+			out = append(out, u)
+		}
+	}
+	return out
 }
 
-// AddUnit registers a new Footman with the military system.
-func (m *Military) AddUnit(f *Footman) {
-	if f != nil {
-		m.units = append(m.units, f)
+// SetRally sends the selected units to rally at p, engaging grunts that come
+// within the aggro radius.
+func (m *Military) SetRally(p Point) { m.order(p, StanceRally) }
+
+// Hold sends the selected units to hold the chokepoint at p, fighting only
+// grunts that reach it.
+func (m *Military) Hold(p Point) { m.order(p, StanceHold) }
+
+// HoldPosition makes each selected unit hold the spot it stands on.
+func (m *Military) HoldPosition() {
+	for _, u := range m.selected() {
+		x, y := u.Position()
+		u.SetRally(Point{x, y}, StanceHold)
+		u.body().focus = nil
+	}
+	if m.squad == "" {
+		m.stance, m.hasOrder = StanceHold, false
+	}
+}
+
+// Charge sends the selected units after the nearest grunts anywhere on the
+// field. They fall back to their rally point once the field is clear.
+func (m *Military) Charge() {
+	for _, u := range m.selected() {
+		p, _ := u.Rally()
+		u.SetRally(p, StanceCharge)
+		u.body().focus = nil
+	}
+	if m.squad == "" {
+		m.stance = StanceCharge
+	}
+}
+
+// Retreat walks the selected units back to p without fighting.
+func (m *Military) Retreat(p Point) { m.order(p, StanceRetreat) }
+
+// Focus points the selected units at one enemy until it dies or another
+// order is given.
+func (m *Military) Focus(f foe) {
+	for _, u := range m.selected() {
+		u.body().focus = f
+	}
+}
+
+// order applies a rally point and stance to the selected units. Orders to
+// the whole army also apply to units trained later.
+func (m *Military) order(p Point, stance Stance) {
+	if m.squad == "" {
+		m.rally, m.stance, m.hasOrder = p, stance, true
+	}
+	for _, u := range m.selected() {
+		u.SetRally(p, stance)
+		u.body().focus = nil
 	}
 }
 
@@ -22,30 +146,123 @@ func rectOverlap(ax, ay, aw, ah, bx, by, bw, bh int) bool {
 	return ax < bx+bw && ax+aw > bx && ay < by+bh && ay+ah > by
 }
 
-// Update advances all units, resolves combat with orc grunts, and removes any
-// that are no longer alive.
+// engage points the unit at its focus, or the nearest living grunt it may
+// fight, or clears its target so it returns to its rally point. Rallying
+// units seek grunts within the aggro radius but give up chases beyond the
+// leash; holding units only fight grunts near their point; charging units
+// chase any grunt; retreating units and units without damage never chase.
+// Ties go to the earlier grunt so the choice is deterministic.
+func engage(u *soldier, orcs []*OrcGrunt) {
+	u.target = nil
+	if u.damage <= 0 || u.stance == StanceRetreat {
+		return
+	}
+	if u.focus != nil {
+		if u.focus.Alive() {
+			u.target = u.focus
+			return
+		}
+		u.focus = nil
+	}
+	best := math.Inf(1)
+	for _, o := range orcs {
+		if !o.Alive() {
+			continue
+		}
+		fromRally := math.Hypot(o.pos.X-u.rally.X, o.pos.Y-u.rally.Y)
+		d := math.Hypot(o.pos.X-u.pos.X, o.pos.Y-u.pos.Y)
+		switch u.stance {
+		case StanceHold:
+			if fromRally > footmanHoldRadius {
+				continue
+			}
+		case StanceCharge:
+		default:
+			if d > footmanAggroRadius || fromRally > footmanLeash {
+				continue
+			}
+		}
+		if d < best {
+			best, u.target = d, o
+		}
+	}
+}
+
+// separate sets every unit's push away from units closer than the
+// separation distance. Units on the same spot are split along the y axis by
+// their order so the result is deterministic.
+func (m *Military) separate() {
+	for i, a := range m.units {
+		u := a.body()
+		u.sepX, u.sepY = 0, 0
+		for j, b := range m.units {
+			o := b.body()
+			if i == j || !o.Alive() {
+				continue
+			}
+			dx, dy := u.pos.X-o.pos.X, u.pos.Y-o.pos.Y
+			d := math.Hypot(dx, dy)
+			if d >= footmanSeparation {
+				continue
+			}
+			if d == 0 {
+				dx, dy, d = 0, 1, 1
+				if i < j {
+					dy = -1
+				}
+			}
+			w := (footmanSeparation - d) / footmanSeparation
+			u.sepX += dx / d * w
+			u.sepY += dy / d * w
+		}
+	}
+}
+
+// Update advances all units towards the nearest grunt they may fight or back
+// to their rally point, lets ranged units shoot and Healers heal, resolves
+// melee with orc grunts, and removes any that are no longer alive.
 func (m *Military) Update(dt float64, orcs []*OrcGrunt) []*OrcGrunt {
+	m.separate()
 	for i := 0; i < len(m.units); {
 		u := m.units[i]
+		engage(u.body(), orcs)
 		u.Update(dt)
+		u.act(m, dt)
 		if !u.Alive() {
 			m.units = append(m.units[:i], m.units[i+1:]...)
 			continue
 		}
-		// Combat resolution against orc grunts
+		// Combat resolution against orc grunts, one exchange of blows per
+		// swing
 		fx, fy, fw, fh := u.Hitbox()
-		for _, o := range orcs {
-			if !o.Alive() {
-				continue
+		if s := u.body(); s.swing.Tick(dt) {
+			struck := false
+			if t := s.target; t != nil && t.Alive() {
+				if _, grunt := t.(*OrcGrunt); !grunt {
+					// a focused boss does not strike back
+					if tx, ty, tw, th := t.Hitbox(); rectOverlap(fx, fy, fw, fh, tx, ty, tw, th) {
+						u.melee(t)
+						struck = true
+					}
+				}
 			}
-			ox, oy, ow, oh := o.Hitbox()
-			if rectOverlap(fx, fy, fw, fh, ox, oy, ow, oh) {
-				o.Damage(u.damage)
-				u.Damage(o.AttackDamage())
-				// Immediately check if Footman died and remove from units
-				if !u.Alive() {
-					break // stop further combat for this unit
+			for _, o := range orcs {
+				if !o.Alive() {
+					continue
 				}
+				ox, oy, ow, oh := o.Hitbox()
+				if rectOverlap(fx, fy, fw, fh, ox, oy, ow, oh) {
+					u.melee(o)
+					u.Damage(o.AttackDamage())
+					struck = true
+					// Immediately check if the unit died and remove it
+					if !u.Alive() {
+						break // stop further combat for this unit
+					}
+				}
+			}
+			if struck {
+				s.swing.Reset()
 			}
 		}
 		if !u.Alive() {
@@ -64,8 +281,8 @@ func (m *Military) Update(dt float64, orcs []*OrcGrunt) []*OrcGrunt {
 	return liveOrcs
 }
 
-// Units returns the list of active Footmen.
-func (m *Military) Units() []*Footman { return m.units }
+// Units returns the list of active units.
+func (m *Military) Units() []Unit { return m.units }
 
 // Count returns the number of active units.
 func (m *Military) Count() int { return len(m.units) }
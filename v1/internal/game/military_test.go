@@ -27,3 +27,76 @@ func TestBarracksAddsUnitToMilitary(t *testing.T) {
 		t.Fatalf("military should track spawned unit")
 	}
 }
+
+func TestMilitaryChargeIgnoresAggroRadius(t *testing.T) {
+	m := NewMilitary()
+	f := NewFootman(0, 0)
+	m.AddUnit(f)
+	o := NewOrcGrunt(600, 0)
+	o.speed = 0
+	m.Update(0.1, []*OrcGrunt{o})
+	if f.target != nil {
+		t.Fatalf("a rallying footman should ignore distant grunts")
+	}
+	m.Charge()
+	m.Update(0.1, []*OrcGrunt{o})
+	if f.target != o {
+		t.Errorf("a charging footman should chase any grunt")
+	}
+}
+
+func TestMilitaryRetreatDoesNotFight(t *testing.T) {
+	m := NewMilitary()
+	f := NewFootman(100, 0)
+	m.AddUnit(f)
+	o := NewOrcGrunt(120, 0)
+	o.speed = 0
+	m.Retreat(Point{0, 0})
+	m.Update(0.1, []*OrcGrunt{o})
+	if f.target != nil {
+		t.Errorf("retreating units should not engage")
+	}
+	if x, _ := f.Position(); x >= 100 {
+		t.Errorf("retreating footman should walk back, at x=%.1f", x)
+	}
+}
+
+func TestMilitarySquadOrders(t *testing.T) {
+	m := NewMilitary()
+	f := NewFootman(0, 0)
+	k := NewUnit(*DefaultRoster().Stats(UnitKnight), 0, 0)
+	m.AddUnit(f)
+	m.AddUnit(k)
+	m.SetSquad(UnitKnight)
+	m.SetRally(Point{200, 0})
+	if p, _ := k.Rally(); p != (Point{200, 0}) {
+		t.Errorf("the knight squad should take the order, rally %v", p)
+	}
+	if p, _ := f.Rally(); p != (Point{0, 0}) {
+		t.Errorf("footmen outside the squad should ignore the order, rally %v", p)
+	}
+	m.SetSquad("all")
+	m.HoldPosition()
+	if _, s := f.Rally(); s != StanceHold {
+		t.Errorf("orders to all should reach every unit, stance %v", s)
+	}
+}
+
+func TestMilitaryFocusOverridesNearest(t *testing.T) {
+	m := NewMilitary()
+	f := NewFootman(0, 0)
+	m.AddUnit(f)
+	near := NewOrcGrunt(50, 0)
+	far := NewOrcGrunt(700, 0)
+	near.speed, far.speed = 0, 0
+	m.Focus(far)
+	m.Update(0.1, []*OrcGrunt{near, far})
+	if f.target != far {
+		t.Fatalf("focused footman should chase its focus")
+	}
+	far.alive = false
+	m.Update(0.1, []*OrcGrunt{near, far})
+	if f.target != near || f.focus != nil {
+		t.Errorf("focus should clear once the enemy dies")
+	}
+}
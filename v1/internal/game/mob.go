@@ -1,6 +1,16 @@
 package game
 
-import "math"
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// shieldWindow is how long a damaged shield has to be broken before it
+// regenerates to full strength.
+const shieldWindow = 3.0
 
 // Mob represents a basic enemy moving left.
 type Mob struct {
@@ -18,6 +28,23 @@ type Mob struct {
 	burstTimer  CooldownTimer // Use proper timer for burst cooldown
 	burstActive CooldownTimer // Use proper timer for burst duration
 	mobType     MobType
+	route       mobRoute // tile path to the base, empty to walk straight
+
+	maxShield    int
+	shieldTimer  CooldownTimer // window to break a damaged shield
+	shieldBroken bool          // broken shields never regenerate
+
+	word  string // word that must be typed to defeat a word mob
+	typed int    // letters of word typed so far
+
+	attack *rangedAttack // ranged enemies stop to shoot defenses in reach
+	layer  TargetLayer   // flying mobs ignore paths and ground-only towers
+
+	affixes   []*AffixDef // rolled modifiers such as elite or splitting
+	maxHealth int         // health cap for regeneration and reflection
+	regen     float64     // health regained per second
+	regenAcc  float64     // fractional health regained so far
+	hasteMult float64     // speed multiplier from nearby hasted auras
 }
 
 // NewMob returns a new mob at the given position.
@@ -59,6 +86,37 @@ func NewFastMob(x, y float64, target *Base, hp int, speed, burst float64) *Mob {
 	return m
 }
 
+// NewShieldedMob creates a mob protected by a regenerating shield. Damage to
+// the shield starts a window; unless the shield is broken before the window
+// ends it regenerates to full.
+func NewShieldedMob(x, y float64, target *Base, hp, shield int, speed float64) *Mob {
+	m := NewMob(x, y, target, hp, speed)
+	m.shield = shield
+	m.maxShield = shield
+	m.shieldTimer = NewCooldownTimer(shieldWindow)
+	m.shieldTimer.remaining = 0 // window starts on the first hit
+	m.mobType = MobShielded
+	return m
+}
+
+// NewWordMob creates a mob that ignores projectiles and is defeated by typing
+// its word. Every correct letter removes one point of health.
+func NewWordMob(x, y float64, target *Base, word string, speed float64) *Mob {
+	m := NewMob(x, y, target, len(word), speed)
+	m.word = word
+	m.mobType = MobWord
+	return m
+}
+
+// NewFlyingMob creates a mob that flies straight at the base over walls and
+// lanes. Only anti-air towers can hit it.
+func NewFlyingMob(x, y float64, target *Base, hp int, speed float64) *Mob {
+	m := NewMob(x, y, target, hp, speed)
+	m.mobType = MobFlyer
+	m.layer = LayerAir
+	return m
+}
+
 // NewBossMob creates a tough boss enemy.
 func NewBossMob(x, y float64, target *Base, hp int, speed float64) *Mob {
 	m := NewMob(x, y, target, hp, speed)
@@ -82,17 +140,32 @@ func (m *Mob) Update(dt float64) error {
 		}
 	}
 
-	// Calculate velocity based on target direction and current speed
-	if m.target != nil {
-		dx := m.target.pos.X - m.pos.X
-		dy := m.target.pos.Y - m.pos.Y
-		dist := math.Hypot(dx, dy)
-		if dist != 0 {
-			m.vx = dx / dist * spd
-			m.vy = dy / dist * spd
+	if m.hasteMult > 0 {
+		spd *= m.hasteMult
+	}
+
+	// Regenerating mobs heal over time up to their spawn health
+	if m.regen > 0 && m.health < m.maxHealth {
+		m.regenAcc += m.regen * dt
+		if heal := int(m.regenAcc); heal > 0 {
+			m.regenAcc -= float64(heal)
+			m.health = min(m.health+heal, m.maxHealth)
 		}
 	}
 
+	// A damaged shield regenerates unless it was broken in time
+	if !m.shieldBroken && m.shield < m.maxShield && !m.shieldTimer.Ready() && m.shieldTimer.Tick(dt) {
+		m.shield = m.maxShield
+	}
+
+	// Calculate velocity towards the next path step, or the base once the
+	// path is exhausted
+	if m.attack != nil && m.attack.holding {
+		m.vx, m.vy = 0, 0
+	} else if vx, vy, ok := m.route.steer(m.pos, m.target, spd, dt); ok {
+		m.vx, m.vy = vx, vy
+	}
+
 	// Update position
 	m.pos.X += m.vx * dt
 	m.pos.Y += m.vy * dt
@@ -115,17 +188,24 @@ func (m *Mob) Velocity() (vx, vy float64) {
 // Alive reports whether the mob is still active.
 func (m *Mob) Alive() bool { return m.alive }
 
-// Damage applies damage considering armor and shields.
+// Damage applies damage considering armor and shields. Word mobs ignore
+// damage and must be typed out instead.
 func (m *Mob) Damage(d int) {
-	if !m.alive {
+	if !m.alive || m.word != "" {
 		return
 	}
 	if m.shield > 0 {
-		m.shield -= d
-		if m.shield < 0 {
-			d = -m.shield
-			m.shield = 0
-		} else {
+		if m.shield == m.maxShield {
+			m.shieldTimer.Reset()
+		}
+		if d < m.shield {
+			m.shield -= d
+			return
+		}
+		d -= m.shield
+		m.shield = 0
+		m.shieldBroken = true
+		if d == 0 {
 			return
 		}
 	}
@@ -143,3 +223,99 @@ func (m *Mob) Damage(d int) {
 
 // Type returns the mob type.
 func (m *Mob) Type() MobType { return m.mobType }
+
+// Layer returns whether the mob is on the ground or in the air.
+func (m *Mob) Layer() TargetLayer { return m.layer }
+
+// kill removes the mob regardless of shields or immunity.
+func (m *Mob) kill() { m.alive = false }
+
+// Shield returns the mob's current shield strength.
+func (m *Mob) Shield() int { return m.shield }
+
+// Word returns a word mob's word and how many letters have been typed.
+func (m *Mob) Word() (string, int) { return m.word, m.typed }
+
+// typeLetter applies a typed letter to a word mob. It returns true when the
+// letter was the next one in the word; a wrong letter restarts the word and
+// gives back the health its letters took.
+func (m *Mob) typeLetter(r rune) bool {
+	if !m.alive || m.typed >= len(m.word) {
+		return false
+	}
+	if rune(m.word[m.typed]) != r {
+		m.health += m.typed
+		m.typed = 0
+		return false
+	}
+	m.typed++
+	m.health--
+	if m.typed >= len(m.word) || m.health <= 0 {
+		m.alive = false
+	}
+	return true
+}
+
+// Draw renders the mob with its shield ring or word label. Flying mobs cast a
+// shadow below them and affixed mobs are tinted with a dot per affix.
+func (m *Mob) Draw(screen *ebiten.Image) {
+	if m.layer == LayerAir {
+		vector.DrawFilledCircle(screen, float32(m.pos.X), float32(m.pos.Y)+float32(m.height)/2+6, float32(m.width)/3, color.RGBA{0, 0, 0, 90}, false)
+	}
+	if len(m.affixes) > 0 {
+		m.drawAffixed(screen)
+	} else {
+		m.BaseEntity.Draw(screen)
+	}
+	if m.shield > 0 {
+		vector.StrokeCircle(screen, float32(m.pos.X), float32(m.pos.Y), float32(m.width), 2, color.RGBA{80, 160, 255, 220}, false)
+	}
+	if m.word == "" {
+		return
+	}
+	x := m.pos.X - float64(len(m.word))*letterWidth/2
+	y := m.pos.Y - float64(m.height) - 8
+	done := &text.DrawOptions{}
+	done.GeoM.Translate(x, y)
+	done.ColorScale.ScaleWithColor(color.RGBA{120, 120, 120, 255})
+	text.Draw(screen, m.word[:m.typed], BoldFont, done)
+	rest := &text.DrawOptions{}
+	rest.GeoM.Translate(x+float64(m.typed)*letterWidth, y)
+	rest.ColorScale.ScaleWithColor(color.White)
+	text.Draw(screen, m.word[m.typed:], BoldFont, rest)
+}
+
+// drawAffixed renders the sprite tinted by the first affix and a row of
+// coloured dots above it, one per affix.
+func (m *Mob) drawAffixed(screen *ebiten.Image) {
+	if m.frame != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(m.pos.X-m.frameAnchorX, m.pos.Y-m.frameAnchorY)
+		op.ColorScale.ScaleWithColor(m.affixes[0].tint())
+		screen.DrawImage(m.frame, op)
+	}
+	x := m.pos.X - float64(len(m.affixes)-1)*4
+	y := m.pos.Y - float64(m.height)/2 - 6
+	for i, a := range m.affixes {
+		vector.DrawFilledCircle(screen, float32(x)+float32(i*8), float32(y), 3, a.tint(), false)
+	}
+}
+
+// newMobOfType creates a mob of the given type with default type traits.
+func newMobOfType(kind MobType, x, y float64, target *Base, hp int, speed float64) *Mob {
+	switch kind {
+	case MobArmored:
+		return NewArmoredMob(x, y, target, hp, 2, speed)
+	case MobFast:
+		return NewFastMob(x, y, target, hp, speed, 2)
+	case MobShielded:
+		return NewShieldedMob(x, y, target, hp, hp, speed)
+	case MobBoss:
+		return NewBossMob(x, y, target, hp, speed)
+	case MobFlyer:
+		return NewFlyingMob(x, y, target, hp, speed)
+	case MobArcher, MobShaman, MobCatapult:
+		return NewRangedMob(kind, x, y, target, hp, speed)
+	}
+	return NewMob(x, y, target, hp, speed)
+}
@@ -88,3 +88,68 @@ func TestMobTypeInstantiation(t *testing.T) {
 		t.Errorf("expected MobBoss type")
 	}
 }
+
+func TestShieldRegeneratesUnlessBroken(t *testing.T) {
+	b := NewBase(0, 0, 1)
+	m := NewShieldedMob(0, 0, b, 5, 4, 0)
+	m.Damage(2)
+	if m.Shield() != 2 || m.health != 5 {
+		t.Fatalf("shield should absorb damage, shield=%d hp=%d", m.Shield(), m.health)
+	}
+	m.Update(shieldWindow + 0.1)
+	if m.Shield() != 4 {
+		t.Fatalf("unbroken shield should regenerate, got %d", m.Shield())
+	}
+
+	m.Damage(3)
+	m.Damage(3)
+	if m.Shield() != 0 || m.health != 3 {
+		t.Fatalf("overflow should reach health, shield=%d hp=%d", m.Shield(), m.health)
+	}
+	m.Update(shieldWindow + 0.1)
+	if m.Shield() != 0 {
+		t.Errorf("broken shield should not regenerate")
+	}
+}
+
+func TestWordMobIgnoresDamage(t *testing.T) {
+	m := NewWordMob(0, 0, NewBase(0, 0, 1), "flask", 0)
+	m.Damage(100)
+	if !m.Alive() || m.health != 5 {
+		t.Fatalf("word mob should ignore projectile damage")
+	}
+	for i, r := range "flas" {
+		if !m.typeLetter(r) || m.health != 5-(i+1) {
+			t.Fatalf("letter %q should deal one damage", r)
+		}
+	}
+	if m.typeLetter('x') || m.health != 5 {
+		t.Fatalf("wrong letter should restart the word, hp %d", m.health)
+	}
+	for _, r := range "flask" {
+		m.typeLetter(r)
+	}
+	if m.Alive() {
+		t.Errorf("typing the whole word should defeat the mob")
+	}
+}
+
+func TestFlyerIgnoresWalls(t *testing.T) {
+	m, err := ParseMap([]byte(wallColumn(30, -1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: m, pathVersion: 1}
+	f := NewFlyingMob(1900, 556, g.base, 3, 10)
+	g.routeMob(f)
+	if len(f.route.steps) != 0 || f.route.version != 1 {
+		t.Fatalf("flyers should not follow a ground path")
+	}
+	f.Update(1)
+	if vx, vy := f.Velocity(); vx >= 0 || vy != 0 {
+		t.Errorf("flyer should head straight for the base, v=(%.1f,%.1f)", vx, vy)
+	}
+	if f.Layer() != LayerAir {
+		t.Errorf("flyer should be in the air layer")
+	}
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+// Game modes offered in PreGame.
+const (
+	ModeClassic = "Classic" // fixed number of waves with a shop between them
+	ModeEndless = "Endless" // timed, overlapping waves that escalate forever
+)
+
+const (
+	classicWaves        = 20   // waves to survive for a Classic victory
+	endlessWaveTime     = 30.0 // seconds before the next Endless wave joins in
+	endlessHPGrowth     = 1.08 // mob health multiplier per Endless wave
+	endlessSpeedGrowth  = 1.02 // mob speed multiplier per Endless wave
+	endlessMaxSpeedMult = 2.0
+)
+
+// Mode returns the run's game mode.
+func (g *Game) Mode() string {
+	if g.mode == "" {
+		return ModeClassic
+	}
+	return g.mode
+}
+
+// SetMode selects Classic or Endless for the run. Unknown modes are ignored.
+func (g *Game) SetMode(mode string) bool {
+	switch mode {
+	case "":
+		mode = ModeClassic
+	case ModeClassic, ModeEndless:
+	default:
+		return false
+	}
+	g.mode = mode
+	return true
+}
+
+// endless reports whether the run is in Endless mode.
+func (g *Game) endless() bool { return g.mode == ModeEndless }
+
+// Victory reports whether a Classic run has been won.
+func (g *Game) Victory() bool { return g.victory }
+
+// classicComplete reports whether the final Classic wave has been cleared.
+func (g *Game) classicComplete() bool {
+	return !g.endless() && g.currentWave >= classicWaves && len(g.pendingSpawns) == 0 && len(g.mobs) == 0
+}
+
+// winRun ends a Classic run with a victory, paying the last wave's King's
+// Points first.
+func (g *Game) winRun() {
+	g.awardWaveKings()
+	g.victory = true
+	g.gameOver = true
+	g.phase = PhaseVictory
+}
+
+// endlessScale returns the exponential health and speed multipliers of the
+// given Endless wave. Classic runs are not scaled.
+func (g *Game) endlessScale(wave int) (hp, speed float64) {
+	if !g.endless() {
+		return 1, 1
+	}
+	n := float64(wave - 1)
+	return math.Pow(endlessHPGrowth, n), math.Min(math.Pow(endlessSpeedGrowth, n), endlessMaxSpeedMult)
+}
+
+// updateEndless spawns the current waves and starts the next one when the
+// wave timer runs out or the field is clear. Waves overlap: spawns still
+// pending from earlier waves stay queued ahead of the new wave's.
+func (g *Game) updateEndless(dt float64) {
+	g.updateSpawns(dt)
+	g.waveTimer += dt
+	g.summaryTimer = math.Max(0, g.summaryTimer-dt)
+	if g.waveTimer < endlessWaveTime && (len(g.pendingSpawns) > 0 || len(g.mobs) > 0) {
+		return
+	}
+	g.awardWaveKings()
+	if g.lastWaveSaved != g.currentWave {
+		g.saveGame(g.currentSavePath())
+		g.lastWaveSaved = g.currentWave
+	}
+	left := append([]waveSpawn(nil), g.pendingSpawns...)
+	ticker := g.spawnTicker
+	g.currentWave++
+	g.startWave()
+	g.pendingSpawns = append(left, g.pendingSpawns...)
+	g.waveSize = len(g.pendingSpawns)
+	g.spawnTicker = ticker
+	g.waveTimer = 0
+}
+
+// modeStatus is the HUD line showing wave progress, e.g. "Wave 3/20" or
+// "Wave 7 - next in 12s - Score 340".
+func (g *Game) modeStatus() string {
+	if g.endless() {
+		return fmt.Sprintf("Wave %d - next in %.0fs - Score %d", g.currentWave, math.Max(0, endlessWaveTime-g.waveTimer), g.score)
+	}
+	return fmt.Sprintf("Wave %d/%d", g.currentWave, classicWaves)
+}
+
+// runSummary returns the end screen lines. Endless runs lead with the score
+// and the best score on the same mode and difficulty.
+func (g *Game) runSummary() []string {
+	var lines []string
+	if g.endless() {
+		lines = append(lines,
+			fmt.Sprintf("Final Score: %d", g.score),
+			fmt.Sprintf("Reached Wave %d", g.currentWave))
+		if g.history != nil {
+			lines = append(lines, fmt.Sprintf("Best Endless Score (%s): %d", g.difficulty.Name, g.history.BestScore(ModeEndless, g.difficulty.Name)))
+		}
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("Score: %d", g.score),
+			fmt.Sprintf("Waves: %d/%d", min(g.currentWave, classicWaves), classicWaves))
+	}
+	return append(lines,
+		fmt.Sprintf("Accuracy: %.0f%%", g.typing.Accuracy()*100),
+		fmt.Sprintf("WPM: %.1f", g.EffectiveWPM()))
+}
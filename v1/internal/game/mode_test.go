@@ -0,0 +1,135 @@
+//go:build test
+
+package game
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestClassicVictoryAfterFinalWave(t *testing.T) {
+	g := NewGame()
+	g.phase = PhasePlaying
+	g.input = &stubInput{}
+	g.currentWave = classicWaves
+	g.pendingSpawns = nil
+	g.mobs = nil
+	if err := g.Step(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Victory() || g.phase != PhaseVictory {
+		t.Fatalf("clearing wave %d should win, phase %v", classicWaves, g.phase)
+	}
+	if g.shopOpen {
+		t.Errorf("the shop should not open after the final wave")
+	}
+	if n := len(g.history.Records); n != 1 || g.history.Records[0].Mode != ModeClassic {
+		t.Errorf("victory should be recorded as a Classic run, got %+v", g.history.Records)
+	}
+}
+
+func TestEndlessSkipsShopAndAdvances(t *testing.T) {
+	g := NewGame()
+	g.saveDir = t.TempDir()
+	g.SetMode(ModeEndless)
+	g.phase = PhasePlaying
+	g.input = &stubInput{}
+	g.pendingSpawns = nil
+	g.mobs = nil
+	if err := g.Step(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if g.shopOpen {
+		t.Errorf("Endless mode should not open the shop")
+	}
+	if g.currentWave != 2 {
+		t.Errorf("a clear field should start the next Endless wave, wave %d", g.currentWave)
+	}
+}
+
+func TestEndlessWavesOverlap(t *testing.T) {
+	g := NewGame()
+	g.saveDir = t.TempDir()
+	g.SetMode(ModeEndless)
+	pending := len(g.pendingSpawns)
+	g.waveTimer = endlessWaveTime
+	g.updateEndless(0)
+	if g.currentWave != 2 {
+		t.Fatalf("wave timer should start wave 2, wave %d", g.currentWave)
+	}
+	if len(g.pendingSpawns) <= pending {
+		t.Errorf("wave 2 spawns should queue behind wave 1's, have %d pending", len(g.pendingSpawns))
+	}
+}
+
+func TestEndlessScalingIsExponential(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, mode: ModeEndless}
+	hp, speed := g.endlessScale(11)
+	if hp < 2.15 || hp > 2.17 {
+		t.Errorf("wave 11 health scale should be 1.08^10, got %v", hp)
+	}
+	if speed <= 1 || speed > endlessMaxSpeedMult {
+		t.Errorf("speed scale out of range: %v", speed)
+	}
+	g.mode = ModeClassic
+	if hp, speed := g.endlessScale(11); hp != 1 || speed != 1 {
+		t.Errorf("Classic runs should not scale, got %v %v", hp, speed)
+	}
+}
+
+func TestLeftoverSpawnKeepsItsWave(t *testing.T) {
+	g := NewGame()
+	g.SetMode(ModeEndless)
+	g.affixScale = 0
+	g.mobs = nil
+	g.pendingSpawns = nil
+	g.scheduleWave()
+	s := g.pendingSpawns[0]
+	g.spawnMob(s)
+	fresh := g.mobs[0].(*Mob).health
+	g.currentWave = 5
+	g.spawnMob(s)
+	if left := g.mobs[1].(*Mob).health; left != fresh {
+		t.Errorf("a wave 1 spawn left over into wave 5 has %d HP, want %d", left, fresh)
+	}
+}
+
+func TestPreGameAppliesMode(t *testing.T) {
+	g := NewGame()
+	g.phase = PhasePreGame
+	g.preGame = NewPreGame()
+	g.preGame.step = 4
+	g.preGame.modeCursor = 1
+	g.input = &pgInput{enter: true}
+	if err := g.preGame.Update(g, 0); err != nil {
+		t.Fatal(err)
+	}
+	if g.Mode() != ModeEndless {
+		t.Errorf("expected Endless, got %s", g.Mode())
+	}
+}
+
+func TestSaveKeepsMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.json")
+	g := NewGame()
+	g.SetMode(ModeEndless)
+	g.saveGame(path)
+	l := NewGame()
+	if err := l.loadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if l.Mode() != ModeEndless {
+		t.Errorf("loaded mode %s, want Endless", l.Mode())
+	}
+}
+
+func TestBestScoreByMode(t *testing.T) {
+	h := &PerformanceHistory{Records: []Performance{
+		{Mode: ModeEndless, Difficulty: "Normal", Score: 500},
+		{Mode: ModeClassic, Difficulty: "Normal", Score: 900},
+		{Mode: ModeEndless, Difficulty: "Hard", Score: 700},
+	}}
+	if got := h.BestScore(ModeEndless, "Normal"); got != 500 {
+		t.Errorf("best Endless Normal score %d, want 500", got)
+	}
+}
@@ -1,14 +1,29 @@
 package game
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"math"
 
-// OrcGrunt represents a basic enemy foot soldier.
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	gruntBaseHP         = 5   // hit points before wave scaling
+	gruntAttackInterval = 2.0 // seconds between blows against the base
+)
+
+// OrcGrunt represents a basic enemy foot soldier. Grunts fight Footmen they
+// run into and, instead of dying on contact with the base, stand and attack
+// it until killed.
 type OrcGrunt struct {
 	BaseEntity
-	hp     int     // current hit points
-	damage int     // melee damage dealt on contact
-	speed  float64 // movement speed in pixels/sec
-	alive  bool    // whether the grunt is active
+	hp          int     // current hit points
+	damage      int     // melee damage dealt on contact
+	speed       float64 // movement speed in pixels/sec
+	alive       bool    // whether the grunt is active
+	vx, vy      float64
+	target      *Base // base to march on; nil walks straight left
+	route       mobRoute
+	attackTimer CooldownTimer
 }
 
 // NewOrcGrunt creates a new orc grunt at the given position.
@@ -23,25 +38,58 @@ func NewOrcGrunt(x, y float64) *OrcGrunt {
 			frameAnchorX: float64(w) / 2,
 			frameAnchorY: float64(h) / 2,
 		},
-		hp:     5,
-		damage: 1,
-		speed:  20,
-		alive:  true,
+		hp:          gruntBaseHP,
+		damage:      1,
+		speed:       20,
+		alive:       true,
+		attackTimer: NewCooldownTimer(gruntAttackInterval),
 	}
 }
 
-// Update moves the grunt to the left and checks for death.
+// Update follows the grunt's route towards its target base, attacking the
+// base once in reach. Without a target it walks straight left.
 func (o *OrcGrunt) Update(dt float64) error {
 	if !o.alive {
 		return nil
 	}
-	o.pos.X -= o.speed * dt
+	if o.atBase() {
+		o.vx, o.vy = 0, 0
+		if o.attackTimer.Tick(dt) {
+			o.target.Damage(o.damage)
+			o.attackTimer.Reset()
+		}
+	} else if vx, vy, ok := o.route.steer(o.pos, o.target, o.speed, dt); ok {
+		o.vx, o.vy = vx, vy
+	} else if o.target == nil {
+		o.vx, o.vy = -o.speed, 0
+	}
+	o.pos.X += o.vx * dt
+	o.pos.Y += o.vy * dt
 	if o.hp <= 0 {
 		o.alive = false
 	}
 	return nil
 }
 
+// atBase reports whether the grunt is close enough to strike its target.
+func (o *OrcGrunt) atBase() bool {
+	if o.target == nil {
+		return false
+	}
+	bx, by, bw, bh := o.target.Bounds()
+	d := math.Hypot(o.pos.X-float64(bx+bw/2), o.pos.Y-float64(by+bh/2))
+	return d < float64(o.width/2+bw/2)
+}
+
+// Velocity returns the grunt's current velocity components.
+func (o *OrcGrunt) Velocity() (vx, vy float64) { return o.vx, o.vy }
+
+// Type identifies grunts to towers and wave scripts.
+func (o *OrcGrunt) Type() MobType { return MobGrunt }
+
+// Layer reports that grunts fight on the ground.
+func (o *OrcGrunt) Layer() TargetLayer { return LayerGround }
+
 // Alive reports whether the grunt is still active.
 func (o *OrcGrunt) Alive() bool { return o.alive }
 
@@ -64,3 +112,26 @@ func (o *OrcGrunt) AttackDamage() int { return o.damage }
 
 // Frame satisfies the Entity interface for OrcGrunt.
 func (o *OrcGrunt) Frame() *ebiten.Image { return o.frame }
+
+// grunts returns the orc grunts currently on the field.
+func (g *Game) grunts() []*OrcGrunt {
+	var out []*OrcGrunt
+	for _, e := range g.mobs {
+		if o, ok := e.(*OrcGrunt); ok {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// spawnGrunt puts a grunt with the given health on the field, marching on the
+// base through the waypoints.
+func (g *Game) spawnGrunt(x, y float64, hp int, waypoints [][2]int) *OrcGrunt {
+	o := NewOrcGrunt(x, y)
+	o.hp = hp
+	o.target = g.base
+	o.route.waypoints = waypoints
+	g.planRoute(o.pos, &o.route)
+	g.mobs = append(g.mobs, o)
+	return o
+}
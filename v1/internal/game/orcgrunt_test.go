@@ -23,7 +23,7 @@ func TestCombatFootmanKillsGrunt(t *testing.T) {
 	m := NewMilitary()
 	m.AddUnit(f)
 
-	for i := 0; i < 6; i++ {
+	for i := 0; i < 25; i++ {
 		o.Update(0.1)
 		m.Update(0.1, []*OrcGrunt{o})
 	}
@@ -54,3 +54,55 @@ func TestCombatFootmanDies(t *testing.T) {
 		t.Errorf("expected footman removed after death")
 	}
 }
+
+// TestGruntAttacksBaseOverTime checks grunts stop at the base and strike it
+// on an interval instead of dying on contact.
+func TestGruntAttacksBaseOverTime(t *testing.T) {
+	b := NewBase(100, 100, 10)
+	o := NewOrcGrunt(100, 100)
+	o.target = b
+	o.Update(gruntAttackInterval / 2)
+	if b.Health() != 10 {
+		t.Fatalf("grunt should wind up before striking")
+	}
+	o.Update(gruntAttackInterval / 2)
+	if b.Health() != 9 || !o.Alive() {
+		t.Fatalf("expected one blow and a living grunt, hp=%d", b.Health())
+	}
+	if x, y := o.Position(); x != 100 || y != 100 {
+		t.Errorf("grunt should hold position while attacking")
+	}
+}
+
+// TestGruntIsTargetableEnemy ensures wave-spawned grunts enter the enemy list
+// as a distinct type towers can shoot.
+func TestGruntIsTargetableEnemy(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1, currentWave: 1}
+	g.spawnMob(waveSpawn{group: WaveGroup{Mob: "grunt", Count: 1}, hpScale: 1})
+	if len(g.mobs) != 1 || g.mobs[0].Type() != MobGrunt {
+		t.Fatalf("expected a grunt enemy")
+	}
+	if !shootable(g.mobs[0]) {
+		t.Errorf("towers should be able to target grunts")
+	}
+	if len(g.grunts()) != 1 {
+		t.Errorf("grunts should be handed to the military")
+	}
+}
+
+// TestFootmanEngagesNearestGrunt verifies footmen close on grunts off their
+// row instead of walking past them.
+func TestFootmanEngagesNearestGrunt(t *testing.T) {
+	f := NewFootman(0, 0)
+	near := NewOrcGrunt(0, 100)
+	far := NewOrcGrunt(0, 300)
+	m := NewMilitary()
+	m.AddUnit(f)
+	m.Update(1, []*OrcGrunt{far, near})
+	if f.target != near {
+		t.Fatalf("footman should pick the nearest grunt")
+	}
+	if x, y := f.Position(); x != 0 || y != 50 {
+		t.Errorf("footman should move towards the grunt, at %.0f,%.0f", x, y)
+	}
+}
@@ -10,12 +10,16 @@ const (
 var FamilyPalette = map[string]string{
 	"Gathering": "\033[32m", // green
 	"Military":  "\033[31m", // red
+	"Repair":    "\033[33m", // yellow
+	"Magic":     "\033[34m", // blue
 }
 
 // FamilyColors maps building families to on-screen colours used by the HUD.
 var FamilyColors = map[string]color.RGBA{
-	"Gathering": {0, 255, 0, 255}, // green
-	"Military":  {255, 0, 0, 255}, // red
+	"Gathering": {0, 255, 0, 255},    // green
+	"Military":  {255, 0, 0, 255},    // red
+	"Repair":    {255, 220, 0, 255},  // yellow
+	"Magic":     {80, 150, 255, 255}, // blue
 }
 
 // FamilyColor returns the colour for the given building family.
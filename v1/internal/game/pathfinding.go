@@ -0,0 +1,338 @@
+package game
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Size of the tile grid enemies path over.
+const (
+	gridCols = 60
+	gridRows = 34
+)
+
+// gridDirs are the four steps a path may take between tiles.
+var gridDirs = [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// inGrid reports whether the tile lies on the grid.
+func inGrid(x, y int) bool {
+	return x >= 0 && x < gridCols && y >= 0 && y < gridRows
+}
+
+// pathNode is an entry in the A* open set.
+type pathNode struct {
+	tile [2]int
+	f    int // cost so far plus heuristic
+	g    int // cost so far
+}
+
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int { return len(q) }
+func (q pathQueue) Less(i, j int) bool {
+	if q[i].f == q[j].f {
+		return q[i].g > q[j].g // prefer nodes closer to the goal
+	}
+	return q[i].f < q[j].f
+}
+func (q pathQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x any)   { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// findPath returns the tiles from start to the nearest goal, avoiding blocked
+// tiles, using A* with a Manhattan heuristic. The start tile is always
+// considered passable. It returns nil if no goal can be reached.
+func findPath(blocked func(x, y int) bool, start [2]int, goals [][2]int) [][2]int {
+	if len(goals) == 0 {
+		return nil
+	}
+	isGoal := make(map[[2]int]bool, len(goals))
+	for _, g := range goals {
+		isGoal[g] = true
+	}
+	h := func(t [2]int) int {
+		best := -1
+		for _, g := range goals {
+			d := abs(t[0]-g[0]) + abs(t[1]-g[1])
+			if best < 0 || d < best {
+				best = d
+			}
+		}
+		return best
+	}
+	cost := map[[2]int]int{start: 0}
+	from := map[[2]int][2]int{}
+	open := &pathQueue{{tile: start, f: h(start)}}
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(pathNode)
+		if cur.g > cost[cur.tile] {
+			continue // stale entry
+		}
+		if isGoal[cur.tile] {
+			path := [][2]int{cur.tile}
+			for t := cur.tile; t != start; {
+				t = from[t]
+				path = append(path, t)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		for _, d := range gridDirs {
+			n := [2]int{cur.tile[0] + d[0], cur.tile[1] + d[1]}
+			if !inGrid(n[0], n[1]) || (blocked(n[0], n[1]) && !isGoal[n]) {
+				continue
+			}
+			g := cur.g + 1
+			if old, ok := cost[n]; ok && old <= g {
+				continue
+			}
+			cost[n] = g
+			from[n] = cur.tile
+			heap.Push(open, pathNode{tile: n, f: g + h(n), g: g})
+		}
+	}
+	return nil
+}
+
+// reachableFrom returns every open tile connected to the sources.
+func reachableFrom(blocked func(x, y int) bool, sources [][2]int) map[[2]int]bool {
+	seen := make(map[[2]int]bool, gridCols*gridRows)
+	queue := append([][2]int(nil), sources...)
+	for _, s := range sources {
+		seen[s] = true
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, d := range gridDirs {
+			n := [2]int{cur[0] + d[0], cur[1] + d[1]}
+			if !inGrid(n[0], n[1]) || seen[n] || blocked(n[0], n[1]) {
+				continue
+			}
+			seen[n] = true
+			queue = append(queue, n)
+		}
+	}
+	return seen
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// routeStep is a point on a mob's path. Steps that complete a lane waypoint
+// are flagged so the route can drop the waypoint once it is reached.
+type routeStep struct {
+	pt       Point
+	waypoint bool
+}
+
+// mobRoute is the path a mob follows to the base.
+type mobRoute struct {
+	waypoints [][2]int    // lane waypoints still to visit
+	steps     []routeStep // tile centres leading to the base
+	version   int         // path version the steps were planned for
+}
+
+// next returns the point the mob should currently head for.
+func (r *mobRoute) next() (Point, bool) {
+	if len(r.steps) == 0 {
+		return Point{}, false
+	}
+	return r.steps[0].pt, true
+}
+
+// advance drops the current step, and its waypoint if it completes one.
+func (r *mobRoute) advance() {
+	if len(r.steps) == 0 {
+		return
+	}
+	if r.steps[0].waypoint && len(r.waypoints) > 0 {
+		r.waypoints = r.waypoints[1:]
+	}
+	r.steps = r.steps[1:]
+}
+
+// steer returns the velocity that moves at spd from pos towards the route's
+// next step, or the target base once the route is exhausted. Reaching a step
+// within this frame advances the route. ok is false when there is no goal or
+// the goal is exactly at pos.
+func (r *mobRoute) steer(pos Point, target *Base, spd, dt float64) (vx, vy float64, ok bool) {
+	goal, routed := r.next()
+	if !routed && target != nil {
+		goal, routed = target.pos, true
+	}
+	if !routed {
+		return 0, 0, false
+	}
+	dx := goal.X - pos.X
+	dy := goal.Y - pos.Y
+	dist := math.Hypot(dx, dy)
+	if dist <= spd*dt && len(r.steps) > 0 {
+		r.advance()
+	}
+	if dist == 0 {
+		return 0, 0, false
+	}
+	return dx / dist * spd, dy / dist * spd, true
+}
+
+// tileCenter returns the screen position of the centre of a tile.
+func tileCenter(t [2]int) Point {
+	x, y := tilePosition(t[0], t[1])
+	return Point{float64(x + TileSize/2), float64(y + TileSize/2)}
+}
+
+// baseTiles returns the tiles covered by the base. They are the goal of every
+// path and are never blocked.
+func (g *Game) baseTiles() [][2]int {
+	if g.base == nil {
+		return nil
+	}
+	bx, by, bw, bh := g.base.Bounds()
+	var tiles [][2]int
+	for x := 0; x < gridCols; x++ {
+		for y := 0; y < gridRows; y++ {
+			c := tileCenter([2]int{x, y})
+			if int(c.X) >= bx && int(c.X) <= bx+bw && int(c.Y) >= by && int(c.Y) <= by+bh {
+				tiles = append(tiles, [2]int{x, y})
+			}
+		}
+	}
+	return tiles
+}
+
+// blockedFunc returns a predicate reporting impassable tiles: walls, towers
+// and houses, plus the extra tile if one is given. Base tiles stay open.
+func (g *Game) blockedFunc(extra *[2]int) func(x, y int) bool {
+	occupied := make(map[[2]int]bool, len(g.towers)+1)
+	for _, t := range g.towers {
+		x, y := towerTile(t)
+		occupied[[2]int{x, y}] = true
+	}
+	for _, h := range g.houseTiles {
+		occupied[h] = true
+	}
+	if extra != nil {
+		occupied[*extra] = true
+	}
+	for _, b := range g.baseTiles() {
+		delete(occupied, b)
+	}
+	return func(x, y int) bool {
+		return occupied[[2]int{x, y}] || g.gameMap.IsWall(x, y)
+	}
+}
+
+// sealsBase reports whether blocking the given tile would cut any spawn tile
+// or lane waypoint off from the base.
+func (g *Game) sealsBase(tileX, tileY int) bool {
+	goals := g.baseTiles()
+	if len(goals) == 0 {
+		return false
+	}
+	extra := [2]int{tileX, tileY}
+	blocked := g.blockedFunc(&extra)
+	seen := reachableFrom(blocked, goals)
+	for _, t := range g.spawnTiles(blocked) {
+		if !seen[t] {
+			return true
+		}
+	}
+	if g.gameMap != nil {
+		for _, l := range g.gameMap.Lanes {
+			for _, w := range l.Waypoints {
+				if !seen[w] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// spawnTiles returns the open tiles on the spawn edge mobs may enter from.
+func (g *Game) spawnTiles(blocked func(x, y int) bool) [][2]int {
+	var tiles [][2]int
+	for row := 0; row < waveRows; row++ {
+		if !blocked(gridCols-1, row) {
+			tiles = append(tiles, [2]int{gridCols - 1, row})
+		}
+	}
+	return tiles
+}
+
+// routeMob plans the path from the mob's tile through its remaining lane
+// waypoints to the base. Without a route the mob walks straight at the base.
+func (g *Game) routeMob(m *Mob) {
+	if m.layer == LayerAir {
+		// Flyers head straight for the base
+		m.route = mobRoute{version: g.pathVersion}
+		return
+	}
+	g.planRoute(m.pos, &m.route)
+}
+
+// planRoute fills r with the path from pos through its remaining waypoints to
+// the base.
+func (g *Game) planRoute(pos Point, r *mobRoute) {
+	r.version = g.pathVersion
+	r.steps = r.steps[:0]
+	goals := g.baseTiles()
+	if len(goals) == 0 {
+		return
+	}
+	blocked := g.blockedFunc(nil)
+	x, y := tileAtPosition(int(pos.X), int(pos.Y))
+	cur := [2]int{x, y}
+	var steps []routeStep
+	for _, w := range r.waypoints {
+		leg := findPath(blocked, cur, [][2]int{w})
+		if leg == nil {
+			return
+		}
+		for i, t := range leg[1:] {
+			steps = append(steps, routeStep{pt: tileCenter(t), waypoint: i == len(leg)-2})
+		}
+		if len(leg) == 1 {
+			// Already standing on the waypoint
+			steps = append(steps, routeStep{pt: tileCenter(w), waypoint: true})
+		}
+		cur = w
+	}
+	leg := findPath(blocked, cur, goals)
+	if leg == nil {
+		return
+	}
+	for _, t := range leg[1:] {
+		steps = append(steps, routeStep{pt: tileCenter(t)})
+	}
+	r.steps = steps
+}
+
+// reroute plans the enemy's path again if towers or walls changed since it
+// was last planned.
+func (g *Game) reroute(e Enemy) {
+	if o, ok := e.(*OrcGrunt); ok {
+		if o.route.version != g.pathVersion {
+			g.planRoute(o.pos, &o.route)
+		}
+	} else if m, ok := asMob(e); ok && m.route.version != g.pathVersion {
+		g.routeMob(m)
+	}
+}
+
+// invalidatePaths makes every mob plan its path again on its next update.
+func (g *Game) invalidatePaths() {
+	g.pathVersion++
+}
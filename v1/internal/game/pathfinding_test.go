@@ -0,0 +1,120 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// wallColumn returns map YAML with a wall down column x except at the gap row.
+func wallColumn(x, gap int) string {
+	var sb strings.Builder
+	sb.WriteString("walls:\n")
+	for y := 0; y < gridRows; y++ {
+		if y != gap {
+			fmt.Fprintf(&sb, "  - [%d, %d]\n", x, y)
+		}
+	}
+	sb.WriteString("lanes:\n  - name: east\n    waypoints: [[59, 20]]\n")
+	return sb.String()
+}
+
+func TestFindPathRoutesAroundWalls(t *testing.T) {
+	m, err := ParseMap([]byte(wallColumn(30, 5)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := findPath(func(x, y int) bool { return m.IsWall(x, y) }, [2]int{59, 20}, [][2]int{{2, 16}})
+	if path == nil {
+		t.Fatal("expected a path through the gap")
+	}
+	through := false
+	for _, p := range path {
+		if m.IsWall(p[0], p[1]) {
+			t.Fatalf("path crosses wall at %v", p)
+		}
+		if p == [2]int{30, 5} {
+			through = true
+		}
+	}
+	if !through {
+		t.Errorf("path should use the gap at (30,5)")
+	}
+	if path[0] != [2]int{59, 20} || path[len(path)-1] != [2]int{2, 16} {
+		t.Errorf("path endpoints wrong: %v ... %v", path[0], path[len(path)-1])
+	}
+}
+
+func TestFindPathNoRoute(t *testing.T) {
+	m, _ := ParseMap([]byte(wallColumn(30, -1)))
+	if p := findPath(func(x, y int) bool { return m.IsWall(x, y) }, [2]int{59, 20}, [][2]int{{2, 16}}); p != nil {
+		t.Errorf("expected no path, got %d steps", len(p))
+	}
+}
+
+func TestPlacementThatSealsBaseIsRejected(t *testing.T) {
+	m, err := ParseMap([]byte(wallColumn(30, 5)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: m}
+	if g.validTowerPosition(30, 5) {
+		t.Errorf("closing the only gap should be rejected")
+	}
+	if g.validTowerPosition(30, 10) {
+		t.Errorf("building on a wall should be rejected")
+	}
+	if g.validTowerPosition(59, 20) {
+		t.Errorf("building on a lane waypoint should be rejected")
+	}
+	if !g.validTowerPosition(45, 25) {
+		t.Errorf("harmless placement should be allowed")
+	}
+}
+
+func TestRouteMobFollowsLaneWaypoints(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1}
+	lane, _ := g.gameMap.Lane("north")
+	start := tileCenter(lane.Waypoints[0])
+	m := NewMob(start.X, start.Y, g.base, 1, 1)
+	m.route.waypoints = lane.Waypoints[1:]
+	g.routeMob(m)
+	if len(m.route.steps) == 0 || m.route.version != 1 {
+		t.Fatal("mob was not routed")
+	}
+	hit := false
+	for _, s := range m.route.steps {
+		if s.waypoint && s.pt == tileCenter(lane.Waypoints[1]) {
+			hit = true
+		}
+	}
+	if !hit {
+		t.Errorf("route should pass the lane waypoint")
+	}
+	for len(m.route.steps) > 0 {
+		m.route.advance()
+	}
+	if len(m.route.waypoints) != 0 {
+		t.Errorf("waypoint should be consumed once reached")
+	}
+}
+
+func TestCheckLanes(t *testing.T) {
+	s, err := ParseWaveScript([]byte("waves:\n  - groups:\n      - {mob: basic, count: 1, lane: moat}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.CheckLanes(DefaultMap()) == nil {
+		t.Errorf("unknown lane should be reported")
+	}
+	s.Waves[0].Groups[0].Lane = "north"
+	if err := s.CheckLanes(DefaultMap()); err != nil {
+		t.Errorf("known lane rejected: %v", err)
+	}
+}
+
+func TestMapFileParses(t *testing.T) {
+	if _, err := LoadMap("../../" + MapFile); err != nil {
+		t.Fatalf("load %s: %v", MapFile, err)
+	}
+}
@@ -2,20 +2,34 @@ package game
 
 // Performance represents a single game's typing performance metrics.
 type Performance struct {
-	WPM      float64
-	Accuracy float64
+	WPM        float64
+	Accuracy   float64
+	Difficulty string
+	Mode       string
+	Score      int
+	Wave       int // last wave reached
 }
 
-// PerformanceHistory tracks best stats and historical records.
+// PerformanceHistory tracks best stats and historical records. Leaderboards
+// and achievements are kept per difficulty so an Easy run never competes with
+// a Hard one.
 type PerformanceHistory struct {
 	BestWPM      float64
 	BestAccuracy float64
 	Records      []Performance
+	Achievements map[string][]string // earned achievements by difficulty
 }
 
 // Record adds a new performance entry and updates best metrics.
 func (ph *PerformanceHistory) Record(ts TypingStats) {
-	p := Performance{WPM: ts.WPM(), Accuracy: ts.Accuracy()}
+	ph.RecordRun(ts, Performance{})
+}
+
+// RecordRun adds the entry of a finished run. run carries the difficulty,
+// mode, score and wave; WPM and accuracy are taken from ts.
+func (ph *PerformanceHistory) RecordRun(ts TypingStats, run Performance) {
+	p := run
+	p.WPM, p.Accuracy = ts.WPM(), ts.Accuracy()
 	ph.Records = append(ph.Records, p)
 	if p.WPM > ph.BestWPM {
 		ph.BestWPM = p.WPM
@@ -24,3 +38,59 @@ func (ph *PerformanceHistory) Record(ts TypingStats) {
 		ph.BestAccuracy = p.Accuracy
 	}
 }
+
+// Best returns the best WPM and accuracy recorded on the given difficulty.
+func (ph *PerformanceHistory) Best(difficulty string) Performance {
+	best := Performance{Difficulty: difficulty}
+	for _, p := range ph.Records {
+		if p.Difficulty != difficulty {
+			continue
+		}
+		if p.WPM > best.WPM {
+			best.WPM = p.WPM
+		}
+		if p.Accuracy > best.Accuracy {
+			best.Accuracy = p.Accuracy
+		}
+	}
+	return best
+}
+
+// BestScore returns the highest score recorded on the given mode and
+// difficulty.
+func (ph *PerformanceHistory) BestScore(mode, difficulty string) int {
+	best := 0
+	for _, p := range ph.Records {
+		if p.Mode == mode && p.Difficulty == difficulty && p.Score > best {
+			best = p.Score
+		}
+	}
+	return best
+}
+
+// AddAchievements records achievements earned on the given difficulty,
+// ignoring ones already earned there.
+func (ph *PerformanceHistory) AddAchievements(difficulty string, names []string) {
+	if ph.Achievements == nil {
+		ph.Achievements = make(map[string][]string)
+	}
+	have := ph.Achievements[difficulty]
+	for _, n := range names {
+		dup := false
+		for _, h := range have {
+			if h == n {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			have = append(have, n)
+		}
+	}
+	ph.Achievements[difficulty] = have
+}
+
+// AchievementsFor returns the achievements earned on the given difficulty.
+func (ph *PerformanceHistory) AchievementsFor(difficulty string) []string {
+	return ph.Achievements[difficulty]
+}
@@ -0,0 +1,90 @@
+package game
+
+// typedPhrase tracks progress typing a word in the letter stream. A wrong
+// letter restarts the word, or counts as its first letter if it matches.
+type typedPhrase struct {
+	text  string
+	typed int
+}
+
+// feed advances the phrase and reports whether it was completed.
+func (p *typedPhrase) feed(r rune) bool {
+	switch {
+	case rune(p.text[p.typed]) == r:
+		p.typed++
+	case rune(p.text[0]) == r:
+		p.typed = 1
+	default:
+		p.typed = 0
+	}
+	if p.typed == len(p.text) {
+		p.typed = 0
+		return true
+	}
+	return false
+}
+
+// phraseSet matches typed letters against several phrases and keeps the
+// letters of a phrase in progress from the queue and towers.
+type phraseSet struct {
+	phrases []typedPhrase
+	held    []rune // letters spelling the start of a phrase
+}
+
+// newPhraseSet returns a set matching the given words.
+func newPhraseSet(words ...string) phraseSet {
+	s := phraseSet{}
+	for _, w := range words {
+		s.phrases = append(s.phrases, typedPhrase{text: w})
+	}
+	return s
+}
+
+// text returns the i-th phrase.
+func (s *phraseSet) text(i int) string { return s.phrases[i].text }
+
+// take feeds typed letters to the active phrases and returns the letters left
+// for other readers. Letters are held while they spell the start of a phrase:
+// completing the phrase consumes them, breaking it hands them on. done is
+// called with the index of every completed phrase.
+func (s *phraseSet) take(typed []rune, active func(i int) bool, done func(i int)) []rune {
+	var rest []rune
+	for _, r := range typed {
+		s.held = append(s.held, r)
+		keep, completed := 0, -1
+		for i := range s.phrases {
+			p := &s.phrases[i]
+			if !active(i) {
+				p.typed = 0
+				continue
+			}
+			if p.feed(r) && completed < 0 {
+				completed = i
+			}
+			keep = max(keep, p.typed)
+		}
+		if completed >= 0 {
+			for i := range s.phrases {
+				s.phrases[i].typed = 0
+			}
+			s.held = s.held[:0]
+			done(completed)
+			continue
+		}
+		n := len(s.held) - keep
+		rest = append(rest, s.held[:n]...)
+		s.held = append(s.held[:0], s.held[n:]...)
+	}
+	return rest
+}
+
+// letters returns this frame's typed letters left for the queue and towers
+// once shouts, the class ability, hero words, word mobs and boss phrases took
+// theirs. Before the game
+// loop has routed any letters it is the raw input.
+func (g *Game) letters() []rune {
+	if g.typed == nil {
+		return g.input.TypedChars()
+	}
+	return g.typed
+}
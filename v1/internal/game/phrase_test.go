@@ -0,0 +1,28 @@
+package game
+
+import "testing"
+
+func TestPhraseSetConsumesCompletedPhrase(t *testing.T) {
+	s := newPhraseSet("warhorn")
+	always := func(int) bool { return true }
+	cast := 0
+	rest := s.take([]rune("xwarwarhornf"), always, func(int) { cast++ })
+	if cast != 1 || string(rest) != "xwarf" {
+		t.Errorf("expected one cast and xwarf left, got %d and %q", cast, string(rest))
+	}
+}
+
+func TestPhraseSetReleasesBrokenPhrase(t *testing.T) {
+	s := newPhraseSet("warhorn", "rally")
+	always := func(int) bool { return true }
+	if rest := s.take([]rune("war"), always, func(int) {}); len(rest) != 0 {
+		t.Fatalf("a phrase in progress should hold its letters, got %q", string(rest))
+	}
+	if rest := s.take([]rune("m"), always, func(int) {}); string(rest) != "warm" {
+		t.Errorf("a broken phrase should hand its letters on, got %q", string(rest))
+	}
+	never := func(int) bool { return false }
+	if rest := s.take([]rune("rally"), never, func(int) { t.Errorf("inactive phrase cast") }); string(rest) != "rally" {
+		t.Errorf("inactive phrases should take nothing, got %q", string(rest))
+	}
+}
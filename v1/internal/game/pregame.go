@@ -25,11 +25,22 @@ type PreGame struct {
 
 // NewPreGame returns a PreGame initialized with default options.
 func NewPreGame() *PreGame {
-	return &PreGame{
+	p := &PreGame{
 		charOptions: []string{"Knight", "Archer"},
-		diffOptions: []string{"Easy", "Normal", "Hard"},
 		modeOptions: []string{"Classic", "Endless"},
 	}
+	p.SetDifficulties(DefaultDifficulties)
+	return p
+}
+
+// SetDifficulties offers the given profiles, in order, on the difficulty
+// screen.
+func (p *PreGame) SetDifficulties(list []DifficultyProfile) {
+	p.diffOptions = p.diffOptions[:0]
+	for _, d := range list {
+		p.diffOptions = append(p.diffOptions, d.Name)
+	}
+	p.diffCursor = 0
 }
 
 // Update processes input for the pre-game flow.
@@ -81,6 +92,10 @@ func (p *PreGame) Update(g *Game, dt float64) error {
 			p.modeCursor = (p.modeCursor - 1 + len(p.modeOptions)) % len(p.modeOptions)
 		}
 		if g.input.Enter() {
+			g.SetDifficulty(p.diffOptions[p.diffCursor])
+			g.SetMode(p.modeOptions[p.modeCursor])
+			g.SetClass(p.charOptions[p.charCursor])
+			g.startRun()
 			g.phase = PhasePlaying
 			g.startWave()
 			if g.sound != nil {
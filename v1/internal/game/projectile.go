@@ -1,6 +1,10 @@
 package game
 
-import "math"
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
 
 // calcIntercept returns a normalized direction vector from the shooter position
 // to where the projectile should aim in order to intercept the moving target.
@@ -42,6 +46,32 @@ func calcIntercept(px, py float64, target Enemy, speed float64) (float64, float6
 	return dx / d, dy / d
 }
 
+// projectileHitRadius is the distance at which a projectile connects with an
+// enemy.
+const projectileHitRadius = 16.0
+
+// arcPeak is the apex height in pixels of a ballistic projectile's flight.
+const arcPeak = 120.0
+
+// ProjectileBehavior selects how a projectile travels and deals damage.
+type ProjectileBehavior int
+
+const (
+	ProjectileStraight ProjectileBehavior = iota // fly straight, hit the first enemy
+	ProjectilePierce                             // pass through several enemies
+	ProjectileSplash                             // explode on impact
+	ProjectileHoming                             // steer toward the target
+	ProjectileArc                                // lob to a landing point
+)
+
+// ProjectileProfile bundles the behavior parameters a tower fires with.
+type ProjectileProfile struct {
+	Behavior     ProjectileBehavior
+	Pierce       int     // extra enemies a piercing shot passes through
+	SplashRadius float64 // blast radius for splash and arcing shots
+	TurnRate     float64 // radians per second for homing shots
+}
+
 // Projectile represents a moving projectile toward a target.
 type Projectile struct {
 	BaseEntity
@@ -53,13 +83,30 @@ type Projectile struct {
 	damage int
 	bounce int
 	game   *Game
+
+	profile ProjectileProfile
+	antiAir bool           // fired by an anti-air tower and can hit flyers
+	pierce  int            // remaining enemies a piercing shot may pass through
+	hits    map[Enemy]bool // enemies already damaged by this projectile
+
+	// Ballistic flight
+	origin  Point
+	land    Point
+	flight  float64
+	elapsed float64
+	height  float64
 }
 
 // NewProjectile creates a new projectile aimed at the target.
 func NewProjectile(g *Game, x, y float64, target Enemy, dmg int, speed float64, bounce int) *Projectile {
+	return NewProjectileWithProfile(g, x, y, target, dmg, speed, bounce, ProjectileProfile{})
+}
+
+// NewProjectileWithProfile creates a projectile using the given behavior.
+func NewProjectileWithProfile(g *Game, x, y float64, target Enemy, dmg int, speed float64, bounce int, prof ProjectileProfile) *Projectile {
 	vx, vy := calcIntercept(x, y, target, speed)
 	w, h := ImgProjectile.Bounds().Dx(), ImgProjectile.Bounds().Dy()
-	return &Projectile{
+	p := &Projectile{
 		BaseEntity: BaseEntity{
 			pos:          Point{x, y},
 			width:        w,
@@ -68,56 +115,228 @@ func NewProjectile(g *Game, x, y float64, target Enemy, dmg int, speed float64,
 			frameAnchorX: float64(w) / 2,
 			frameAnchorY: float64(h) / 2,
 		},
-		vx:     vx,
-		vy:     vy,
-		speed:  speed,
-		target: target,
-		alive:  true,
-		damage: dmg,
-		bounce: bounce,
-		game:   g,
+		vx:      vx,
+		vy:      vy,
+		speed:   speed,
+		target:  target,
+		alive:   true,
+		damage:  dmg,
+		bounce:  bounce,
+		game:    g,
+		profile: prof,
+		pierce:  prof.Pierce,
+		hits:    make(map[Enemy]bool),
+	}
+	if prof.Behavior == ProjectileArc {
+		p.origin = Point{x, y}
+		tx, ty := target.Position()
+		tvx, tvy := target.Velocity()
+		flight := 1.0
+		if speed > 0 {
+			flight = math.Hypot(tx-x, ty-y) / speed
+		}
+		p.flight = flight
+		p.land = Point{tx + tvx*flight, ty + tvy*flight}
 	}
+	return p
 }
 
-// Update moves the projectile and checks collision.
+// Update moves the projectile and checks collision against every enemy on
+// the path travelled this frame.
 func (p *Projectile) Update(dt float64) {
+	if p.profile.Behavior == ProjectileArc {
+		p.updateArc(dt)
+		return
+	}
+	if p.profile.Behavior == ProjectileHoming {
+		p.steer(dt)
+	}
+	x0, y0 := p.pos.X, p.pos.Y
 	p.pos.X += p.vx * p.speed * dt
 	p.pos.Y += p.vy * p.speed * dt
-	if p.target != nil && p.target.Alive() {
-		tx, ty := p.target.Position()
-		dx := tx - p.pos.X
-		dy := ty - p.pos.Y
-		if math.Hypot(dx, dy) < 16 {
-			if p.target.Alive() {
-				p.target.Damage(p.damage)
-				if p.bounce > 0 && p.game != nil {
-					p.bounce--
-					// pick new target: closest alive mob
-					var next Enemy
-					dist := math.MaxFloat64
-					for _, m := range p.game.mobs {
-						if m.Alive() && m != p.target {
-							mx, my := m.Position()
-							dx := mx - p.pos.X
-							dy := my - p.pos.Y
-							d := math.Hypot(dx, dy)
-							if d < dist {
-								dist = d
-								next = m
-							}
-						}
-					}
-					if next != nil {
-						p.target = next
-						p.vx, p.vy = calcIntercept(p.pos.X, p.pos.Y, next, p.speed)
-						return
-					}
-				}
-				p.alive = false
-			}
+	for _, m := range p.enemiesOnPath(x0, y0, p.pos.X, p.pos.Y) {
+		if !p.hit(m) {
+			break
 		}
 	}
 	if p.pos.X < -10 || p.pos.X > 1930 || p.pos.Y < -10 || p.pos.Y > 1090 {
 		p.alive = false
 	}
 }
+
+// candidates returns all enemies the projectile may collide with.
+func (p *Projectile) candidates() []Enemy {
+	var out []Enemy
+	if p.game != nil {
+		for _, m := range p.game.mobs {
+			if canHit(m, p.antiAir) {
+				out = append(out, m)
+			}
+		}
+	}
+	if p.target != nil {
+		found := false
+		for _, m := range out {
+			if m == p.target {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, p.target)
+		}
+	}
+	return out
+}
+
+// enemiesOnPath returns alive enemies within hit range of the segment
+// (x0,y0)-(x1,y1), ordered from the start of the segment.
+func (p *Projectile) enemiesOnPath(x0, y0, x1, y1 float64) []Enemy {
+	type onPath struct {
+		m Enemy
+		t float64
+	}
+	var found []onPath
+	dx, dy := x1-x0, y1-y0
+	lenSq := dx*dx + dy*dy
+	for _, m := range p.candidates() {
+		if m == nil || !m.Alive() || p.hits[m] {
+			continue
+		}
+		mx, my := m.Position()
+		t := 0.0
+		if lenSq > 0 {
+			t = ((mx-x0)*dx + (my-y0)*dy) / lenSq
+			t = math.Max(0, math.Min(1, t))
+		}
+		cx, cy := x0+dx*t, y0+dy*t
+		if math.Hypot(mx-cx, my-cy) < projectileHitRadius {
+			found = append(found, onPath{m, t})
+		}
+	}
+	for i := 1; i < len(found); i++ {
+		for j := i; j > 0 && found[j].t < found[j-1].t; j-- {
+			found[j], found[j-1] = found[j-1], found[j]
+		}
+	}
+	out := make([]Enemy, len(found))
+	for i, f := range found {
+		out[i] = f.m
+	}
+	return out
+}
+
+// hit applies the projectile's effect to m. It returns true if the
+// projectile keeps travelling along its current path.
+func (p *Projectile) hit(m Enemy) bool {
+	switch p.profile.Behavior {
+	case ProjectilePierce:
+		m.Damage(p.damage)
+		p.hits[m] = true
+		if p.pierce <= 0 {
+			p.alive = false
+			return false
+		}
+		p.pierce--
+		return true
+	case ProjectileSplash:
+		mx, my := m.Position()
+		p.explode(mx, my)
+		return false
+	}
+	m.Damage(p.damage)
+	p.hits[m] = true
+	if p.bounce > 0 {
+		p.bounce--
+		if next := p.nearestEnemy(); next != nil {
+			p.target = next
+			p.vx, p.vy = calcIntercept(p.pos.X, p.pos.Y, next, p.speed)
+			return false
+		}
+	}
+	p.alive = false
+	return false
+}
+
+// nearestEnemy returns the closest alive enemy not yet hit.
+func (p *Projectile) nearestEnemy() Enemy {
+	var next Enemy
+	dist := math.MaxFloat64
+	for _, m := range p.candidates() {
+		if !m.Alive() || p.hits[m] {
+			continue
+		}
+		mx, my := m.Position()
+		d := math.Hypot(mx-p.pos.X, my-p.pos.Y)
+		if d < dist {
+			dist = d
+			next = m
+		}
+	}
+	return next
+}
+
+// explode damages every alive enemy within the splash radius of (x, y) and
+// removes the projectile.
+func (p *Projectile) explode(x, y float64) {
+	radius := math.Max(p.profile.SplashRadius, projectileHitRadius)
+	for _, m := range p.candidates() {
+		if !m.Alive() {
+			continue
+		}
+		mx, my := m.Position()
+		if math.Hypot(mx-x, my-y) <= radius {
+			m.Damage(p.damage)
+		}
+	}
+	p.alive = false
+}
+
+// steer turns a homing projectile toward its target, limited by the turn
+// rate. A new target is acquired when the current one dies.
+func (p *Projectile) steer(dt float64) {
+	if p.target == nil || !p.target.Alive() {
+		p.target = p.nearestEnemy()
+		if p.target == nil {
+			return
+		}
+	}
+	tx, ty := p.target.Position()
+	want := math.Atan2(ty-p.pos.Y, tx-p.pos.X)
+	cur := math.Atan2(p.vy, p.vx)
+	diff := math.Remainder(want-cur, 2*math.Pi)
+	maxTurn := p.profile.TurnRate * dt
+	if diff > maxTurn {
+		diff = maxTurn
+	} else if diff < -maxTurn {
+		diff = -maxTurn
+	}
+	cur += diff
+	p.vx, p.vy = math.Cos(cur), math.Sin(cur)
+}
+
+// updateArc advances a ballistic projectile. It cannot collide mid-flight and
+// explodes at its landing point.
+func (p *Projectile) updateArc(dt float64) {
+	p.elapsed += dt
+	frac := 1.0
+	if p.flight > 0 {
+		frac = math.Min(p.elapsed/p.flight, 1)
+	}
+	p.pos.X = p.origin.X + (p.land.X-p.origin.X)*frac
+	p.pos.Y = p.origin.Y + (p.land.Y-p.origin.Y)*frac
+	p.height = 4 * arcPeak * frac * (1 - frac)
+	if frac >= 1 {
+		p.explode(p.land.X, p.land.Y)
+	}
+}
+
+// Draw renders the projectile, lifted by its current arc height.
+func (p *Projectile) Draw(screen *ebiten.Image) {
+	if p.frame == nil {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(p.pos.X-p.frameAnchorX, p.pos.Y-p.frameAnchorY-p.height)
+	screen.DrawImage(p.frame, op)
+}
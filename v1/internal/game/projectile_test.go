@@ -15,3 +15,83 @@ func TestProjectileIntercept(t *testing.T) {
 		t.Errorf("projectile did not hit the mob")
 	}
 }
+
+func TestProjectileHitsEnemyOnPath(t *testing.T) {
+	base := NewBase(800, 100, 10)
+	blocker := NewMob(200, 100, base, 1, 0)
+	target := NewMob(400, 100, base, 1, 0)
+	g := &Game{mobs: []Enemy{blocker, target}}
+	p := NewProjectile(g, 100, 100, target, 1, 500, 0)
+	for i := 0; i < 100 && p.alive; i++ {
+		p.Update(0.016)
+	}
+	if blocker.Alive() {
+		t.Errorf("straight projectile should hit the first enemy in its path")
+	}
+	if !target.Alive() {
+		t.Errorf("straight projectile should stop after its first hit")
+	}
+}
+
+func TestProjectilePierce(t *testing.T) {
+	base := NewBase(800, 100, 10)
+	mobs := []*Mob{NewMob(200, 100, base, 1, 0), NewMob(250, 100, base, 1, 0), NewMob(300, 100, base, 1, 0)}
+	g := &Game{mobs: []Enemy{mobs[0], mobs[1], mobs[2]}}
+	p := NewProjectileWithProfile(g, 100, 100, mobs[2], 1, 500, 0, ProjectileProfile{Behavior: ProjectilePierce, Pierce: 1})
+	for i := 0; i < 100 && p.alive; i++ {
+		p.Update(0.016)
+	}
+	if mobs[0].Alive() || mobs[1].Alive() {
+		t.Errorf("pierce 1 should damage the first two enemies")
+	}
+	if !mobs[2].Alive() {
+		t.Errorf("pierce 1 should stop after two enemies")
+	}
+}
+
+func TestProjectileSplash(t *testing.T) {
+	base := NewBase(800, 100, 10)
+	a := NewMob(200, 100, base, 1, 0)
+	b := NewMob(200, 140, base, 1, 0)
+	far := NewMob(200, 300, base, 1, 0)
+	g := &Game{mobs: []Enemy{a, b, far}}
+	p := NewProjectileWithProfile(g, 100, 100, a, 1, 500, 0, ProjectileProfile{Behavior: ProjectileSplash, SplashRadius: 48})
+	for i := 0; i < 100 && p.alive; i++ {
+		p.Update(0.016)
+	}
+	if a.Alive() || b.Alive() {
+		t.Errorf("splash should damage enemies inside the radius")
+	}
+	if !far.Alive() {
+		t.Errorf("splash should not reach enemies outside the radius")
+	}
+}
+
+func TestProjectileHomingTurns(t *testing.T) {
+	base := NewBase(800, 100, 10)
+	mob := NewMob(100, 300, base, 1, 0)
+	g := &Game{mobs: []Enemy{mob}}
+	p := NewProjectileWithProfile(g, 100, 100, mob, 1, 200, 0, ProjectileProfile{Behavior: ProjectileHoming, TurnRate: 10})
+	p.vx, p.vy = 1, 0 // start flying away from the target
+	for i := 0; i < 300 && p.alive; i++ {
+		p.Update(0.016)
+	}
+	if mob.Alive() {
+		t.Errorf("homing projectile should curve back to its target")
+	}
+}
+
+func TestProjectileArcLandsAtPoint(t *testing.T) {
+	base := NewBase(800, 100, 10)
+	mob := NewMob(300, 100, base, 1, 0)
+	g := &Game{mobs: []Enemy{mob}}
+	p := NewProjectileWithProfile(g, 100, 100, mob, 1, 200, 0, ProjectileProfile{Behavior: ProjectileArc, SplashRadius: 32})
+	p.Update(0.5)
+	if !mob.Alive() || p.height <= 0 {
+		t.Fatalf("arcing projectile should be airborne mid-flight")
+	}
+	p.Update(1)
+	if mob.Alive() || p.alive {
+		t.Errorf("arcing projectile should explode at its landing point")
+	}
+}
@@ -15,11 +15,12 @@ type QueueManager struct {
 	base     *Base
 	timer    float64
 	progress int // typed letters progress for first word
+	pressure int // backlog length at which the base takes damage
 }
 
 // NewQueueManager initializes an empty queue.
 func NewQueueManager() *QueueManager {
-	return &QueueManager{queue: make([]Word, 0), base: nil, timer: 0, progress: 0}
+	return &QueueManager{queue: make([]Word, 0), base: nil, timer: 0, progress: 0, pressure: defaultQueuePressure}
 }
 
 // Enqueue adds a word to the end of the queue.
@@ -27,6 +28,21 @@ func (q *QueueManager) Enqueue(w Word) {
 	q.queue = append(q.queue, w)
 }
 
+// Remove deletes the first queued word equal to w. Removing the word being
+// typed resets the typing progress.
+func (q *QueueManager) Remove(w Word) bool {
+	for i, qw := range q.queue {
+		if qw == w {
+			q.queue = append(q.queue[:i], q.queue[i+1:]...)
+			if i == 0 {
+				q.progress = 0
+			}
+			return true
+		}
+	}
+	return false
+}
+
 // Progress returns the completion ratio of the first word, 0-1.
 func (q *QueueManager) Progress() float64 {
 	if len(q.queue) == 0 {
@@ -70,13 +86,22 @@ func (q *QueueManager) TryLetter(r rune) (bool, bool, Word) {
 // SetBase assigns a Base that will take damage from backlog pressure.
 func (q *QueueManager) SetBase(b *Base) { q.base = b }
 
+// SetPressureThreshold changes the backlog length at which the base starts
+// taking damage. Values below 1 restore the default.
+func (q *QueueManager) SetPressureThreshold(n int) {
+	if n < 1 {
+		n = defaultQueuePressure
+	}
+	q.pressure = n
+}
+
 // Update applies back-pressure damage if backlog length exceeds threshold.
 func (q *QueueManager) Update(dt float64) {
 	if q.base == nil {
 		return
 	}
 	// With letter-level queue items, allow a moderate backlog before damage
-	if len(q.queue) >= 6 { // was 20, now 6 to match test expectations
+	if len(q.queue) >= q.pressure {
 		q.timer += dt
 		if q.timer >= 1 {
 			q.base.Damage(1)
@@ -67,3 +67,16 @@ func TestQueueBackPressureDamage(t *testing.T) {
 		t.Fatalf("expected base health 4 got %d", base.Health())
 	}
 }
+
+func TestQueueRemove(t *testing.T) {
+	q := NewQueueManager()
+	q.Enqueue(Word{Text: "ab"})
+	q.Enqueue(Word{Text: "cd"})
+	q.TryLetter('a')
+	if !q.Remove(Word{Text: "ab"}) || q.Index() != 0 {
+		t.Fatalf("removing the head should reset progress")
+	}
+	if q.Remove(Word{Text: "zz"}) || q.Len() != 1 {
+		t.Errorf("unknown word should not be removed")
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import "math/rand"
+
+// ReloadDrill is a word or bigram a tower can be reloaded with. Typing the
+// whole drill loads several ammo slots at once instead of one per letter.
+type ReloadDrill struct {
+	Text string // letters the player must type
+	Ammo int    // ammo slots filled when the drill is completed
+}
+
+// defaultDrills returns the starting drill list for a tower type: home row
+// sequences of the starting letters sized to the tower's magazine. Longer
+// drills are granted later by tech nodes.
+func defaultDrills(tt TowerType) []ReloadDrill {
+	switch tt {
+	case TowerSniper:
+		return []ReloadDrill{{Text: "jfj", Ammo: 3}}
+	case TowerRapid:
+		return []ReloadDrill{{Text: "fjfj", Ammo: 5}, {Text: "jfjf", Ammo: 5}}
+	default:
+		return []ReloadDrill{{Text: "fjfj", Ammo: 5}}
+	}
+}
+
+// AddDrill appends a drill to the tower's drill list, ignoring duplicates.
+func (t *Tower) AddDrill(d ReloadDrill) {
+	if d.Text == "" || d.Ammo < 1 {
+		return
+	}
+	for _, ex := range t.drills {
+		if ex.Text == d.Text {
+			return
+		}
+	}
+	t.drills = append(t.drills, d)
+}
+
+// Drills returns the tower's drill list.
+func (t *Tower) Drills() []ReloadDrill { return t.drills }
+
+// pickDrill selects the highest payoff drill that is typeable with the
+// unlocked letters and no longer than the number of empty slots, so a drill is
+// never worse than reloading letter by letter. Ties are broken randomly. A
+// fixed reload sequence takes precedence over drills.
+func (t *Tower) pickDrill(empty int) (ReloadDrill, bool) {
+	if t.game == nil || len(t.reloadSeq) > 0 {
+		return ReloadDrill{}, false
+	}
+	var best []ReloadDrill
+	for _, d := range t.drills {
+		if len(d.Text) > empty || !wordUsesPool(d.Text, t.game.letterPool) {
+			continue
+		}
+		if len(best) == 0 || d.Ammo > best[0].Ammo {
+			best = []ReloadDrill{d}
+		} else if d.Ammo == best[0].Ammo {
+			best = append(best, d)
+		}
+	}
+	if len(best) == 0 {
+		return ReloadDrill{}, false
+	}
+	return best[rand.Intn(len(best))], true
+}
+
+// startDrill queues the drill letters at the front of the reload queue. The
+// payoff is capped at the number of empty slots.
+func (t *Tower) startDrill(d ReloadDrill, empty int) {
+	t.reloadQueue = append([]rune(d.Text), t.reloadQueue...)
+	t.drillLeft = len(d.Text)
+	t.drillAmmo = d.Ammo
+	if t.drillAmmo > empty {
+		t.drillAmmo = empty
+	}
+}
+
+// pendingAmmo returns how many ammo slots the queued reload letters will fill.
+func (t *Tower) pendingAmmo() int {
+	n := len(t.reloadQueue)
+	if t.drillLeft > 0 {
+		n += t.drillAmmo - t.drillLeft
+	}
+	return n
+}
+
+// loadAmmo fills up to n empty ammo slots.
+func (t *Tower) loadAmmo(n int) {
+	for i := range t.ammoQueue {
+		if n <= 0 {
+			return
+		}
+		if !t.ammoQueue[i] {
+			t.ammoQueue[i] = true
+			n--
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package game
+
+import "testing"
+
+func TestTowerDrillFillsMultipleSlots(t *testing.T) {
+	inp := &stubInput{}
+	g := &Game{cfg: &DefaultConfig, input: inp, typing: NewTypingStats(), letterPool: []rune{'f', 'j'}}
+	tower := NewTower(g, 0, 0)
+	tower.drills = []ReloadDrill{{Text: "fj", Ammo: 3}}
+	for tower.getAvailableAmmo() > 0 {
+		tower.consumeAmmo()
+	}
+	tower.Update(0.016)
+	if tower.drillLeft != 2 || tower.reloadQueue[0] != 'f' || tower.reloadQueue[1] != 'j' {
+		t.Fatalf("expected fj drill queued, got %q", string(tower.reloadQueue))
+	}
+
+	inp.typed = []rune{'f'}
+	tower.Update(0.016)
+	if tower.getAvailableAmmo() != 0 {
+		t.Fatalf("partial drill should not load ammo")
+	}
+	inp.typed = []rune{'j'}
+	tower.Update(0.016)
+	if got := tower.getAvailableAmmo(); got != 3 {
+		t.Fatalf("expected 3 ammo after drill got %d", got)
+	}
+}
+
+func TestPickDrillRequiresUnlockedLetters(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: NewInput(), typing: NewTypingStats(), letterPool: []rune{'f', 'j'}}
+	tower := NewTower(g, 0, 0)
+	tower.drills = []ReloadDrill{{Text: "flask", Ammo: 7}, {Text: "fj", Ammo: 3}}
+	d, ok := tower.pickDrill(5)
+	if !ok || d.Text != "fj" {
+		t.Fatalf("expected fj drill got %+v", d)
+	}
+	g.letterPool = append(g.letterPool, 'l', 'a', 's', 'k')
+	d, _ = tower.pickDrill(5)
+	if d.Text != "flask" {
+		t.Fatalf("expected flask drill once letters unlocked got %s", d.Text)
+	}
+	if _, ok := tower.pickDrill(1); ok {
+		t.Fatalf("drill longer than empty slots should not be picked")
+	}
+}
+
+func TestDefaultDrillsAreSequences(t *testing.T) {
+	for _, tt := range []TowerType{TowerBasic, TowerSniper, TowerRapid} {
+		capacity := DefaultConfig.TowerAmmoCapacity + towerTypeModifier(tt).AmmoAdd
+		for _, d := range defaultDrills(tt) {
+			if len(d.Text) < 3 || len(d.Text) > capacity || !wordUsesPool(d.Text, []rune{'f', 'j'}) {
+				t.Errorf("tower type %d: %q is not a starting drill for %d slots", tt, d.Text, capacity)
+			}
+		}
+	}
+}
+
+func TestChallengeWordSetsDamageBonus(t *testing.T) {
+	inp := &stubInput{}
+	g := &Game{cfg: &DefaultConfig, input: inp, typing: NewTypingStats()}
+	tower := NewTower(g, 0, 0)
+	tower.StartReloadChallenge("flask")
+	inp.typed = []rune("flask")
+	tower.Update(0.016)
+	if tower.challengeActive {
+		t.Fatalf("challenge should be complete")
+	}
+	if tower.damageBonus != 5 || tower.bonusTimer.Ready() {
+		t.Fatalf("expected active damage bonus 5 got %d", tower.damageBonus)
+	}
+	tower.Update(10)
+	if tower.damageBonus != 0 {
+		t.Fatalf("bonus should expire, got %d", tower.damageBonus)
+	}
+}
+
+func TestRandomChallengeWordUsesPool(t *testing.T) {
+	g := &Game{letterPool: []rune{'f', 'l', 'a', 's', 'k'}}
+	w := g.randomChallengeWord()
+	if !wordUsesPool(w, g.letterPool) {
+		t.Fatalf("challenge word %q uses locked letters", w)
+	}
+	g.letterPool = nil
+	if g.randomChallengeWord() != "" {
+		t.Fatalf("expected no challenge without letters")
+	}
+}
@@ -115,6 +115,29 @@ func (f *Food) Amount() int { return f.amount }
 // Set sets the food amount directly.
 func (f *Food) Set(n int) { f.amount = n }
 
+// Mana tracks the magic gathered by the Sanctum for spells.
+type Mana struct {
+	amount int
+}
+
+// Add increases the mana amount.
+func (m *Mana) Add(n int) { m.amount += n }
+
+// Spend subtracts the given amount if available and returns true.
+func (m *Mana) Spend(n int) bool {
+	if m.amount < n {
+		return false
+	}
+	m.amount -= n
+	return true
+}
+
+// Amount returns the current mana total.
+func (m *Mana) Amount() int { return m.amount }
+
+// Set sets the mana amount directly.
+func (m *Mana) Set(n int) { m.amount = n }
+
 // KingsPoints tracks special currency used for letter unlocks.
 type KingsPoints struct {
 	amount int
@@ -145,6 +168,7 @@ type ResourcePool struct {
 	Wood  Wood
 	Stone Stone
 	Iron  Iron
+	Mana  Mana
 	Kings KingsPoints
 }
 
@@ -163,6 +187,9 @@ func (r *ResourcePool) AddStone(n int) { r.Stone.Add(n) }
 // AddIron adds the specified amount of iron.
 func (r *ResourcePool) AddIron(n int) { r.Iron.Add(n) }
 
+// AddMana adds the specified amount of mana.
+func (r *ResourcePool) AddMana(n int) { r.Mana.Add(n) }
+
 // GoldAmount returns the current gold total.
 func (r *ResourcePool) GoldAmount() int { return r.Gold.Amount() }
 
@@ -178,6 +205,9 @@ func (r *ResourcePool) StoneAmount() int { return r.Stone.Amount() }
 // IronAmount returns the current iron total.
 func (r *ResourcePool) IronAmount() int { return r.Iron.Amount() }
 
+// ManaAmount returns the current mana total.
+func (r *ResourcePool) ManaAmount() int { return r.Mana.Amount() }
+
 // AddKingsPoints adds the specified amount of King's Points.
 func (r *ResourcePool) AddKingsPoints(n int) { r.Kings.Add(n) }
 
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// UnitsFile is the default path for the Barracks unit roster.
+const UnitsFile = "units.yaml"
+
+// Unit kinds the Barracks can train. Each roster entry picks its behaviour by
+// id.
+const (
+	UnitFootman = "footman" // melee
+	UnitArcher  = "archer"  // shoots from range
+	UnitKnight  = "knight"  // armored melee
+	UnitHealer  = "healer"  // restores allies and the base
+)
+
+// UnitStats is the data-defined stat line of a unit kind.
+type UnitStats struct {
+	ID       string  `yaml:"id"` // footman, archer, knight or healer
+	Name     string  `yaml:"name"`
+	HP       int     `yaml:"hp"`
+	Damage   int     `yaml:"damage"` // per hit or shot
+	Speed    float64 `yaml:"speed"`  // pixels per second
+	Armor    int     `yaml:"armor"`  // damage ignored per hit, one point always gets through
+	Range    float64 `yaml:"range"`  // shot or heal reach in pixels, 0 for melee
+	Interval float64 `yaml:"interval"`
+	Heal     int     `yaml:"heal"`      // ally hit points restored per heal
+	BaseHeal int     `yaml:"base_heal"` // base hit points restored per heal
+	Supply   int     `yaml:"supply"`    // supply taken, also the Food eaten every wave
+	Cost     Cost    `yaml:"cost"`      // resources spent when a word trains the unit
+	Stage    int     `yaml:"stage"`     // Barracks letter stage that unlocks the unit, 0 when available from the start
+}
+
+// Roster holds the stats of every unit kind the Barracks can train.
+type Roster struct {
+	Units []UnitStats `yaml:"units"`
+}
+
+// defaultUnits is the built-in roster. The Footman comes first.
+var defaultUnits = []UnitStats{
+	{ID: UnitFootman, Name: "Footman", HP: 10, Damage: 1, Speed: 50, Supply: 1},
+	{ID: UnitArcher, Name: "Archer", HP: 6, Damage: 1, Speed: 45, Range: 160, Interval: 1, Supply: 1, Cost: Cost{Wood: 3}, Stage: 2},
+	{ID: UnitKnight, Name: "Knight", HP: 20, Damage: 2, Speed: 35, Armor: 1, Supply: 2, Cost: Cost{Iron: 3}, Stage: 4},
+	{ID: UnitHealer, Name: "Healer", HP: 6, Speed: 45, Range: 120, Interval: 3, Heal: 2, BaseHeal: 1, Supply: 1, Cost: Cost{Gold: 5, Food: 2}, Stage: 6},
+}
+
+// LoadRoster parses a YAML file into a Roster and validates it.
+func LoadRoster(path string) (*Roster, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoster(data)
+}
+
+// ParseRoster parses YAML roster data and validates it.
+func ParseRoster(data []byte) (*Roster, error) {
+	var r Roster
+	if err := yaml.UnmarshalStrict(data, &r); err != nil {
+		return nil, err
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// DefaultRoster returns the built-in unit roster.
+func DefaultRoster() *Roster {
+	return &Roster{Units: append([]UnitStats(nil), defaultUnits...)}
+}
+
+// validate checks ids and value ranges. The Footman must be available from
+// the start since it is what the Barracks trains first.
+func (r *Roster) validate() error {
+	seen := map[string]bool{}
+	for i, u := range r.Units {
+		switch u.ID {
+		case UnitFootman, UnitArcher, UnitKnight, UnitHealer:
+		case "":
+			return fmt.Errorf("unit %d has no id", i+1)
+		default:
+			return fmt.Errorf("unknown unit %q", u.ID)
+		}
+		if seen[u.ID] {
+			return fmt.Errorf("duplicate unit %s", u.ID)
+		}
+		seen[u.ID] = true
+		if u.HP <= 0 {
+			return fmt.Errorf("unit %s needs hp", u.ID)
+		}
+		if u.Damage < 0 || u.Speed < 0 || u.Armor < 0 || u.Range < 0 || u.Interval < 0 || u.Heal < 0 || u.BaseHeal < 0 || u.Supply < 0 || u.Cost.negative() {
+			return fmt.Errorf("unit %s: negative value", u.ID)
+		}
+		if u.Stage < 0 || u.Stage >= len(LetterUnlockStages) {
+			return fmt.Errorf("unit %s: no letter stage %d", u.ID, u.Stage)
+		}
+		if (u.ID == UnitArcher || u.ID == UnitHealer) && (u.Range <= 0 || u.Interval <= 0) {
+			return fmt.Errorf("unit %s needs a range and an interval", u.ID)
+		}
+	}
+	if f := r.Stats(UnitFootman); f == nil || f.Stage != 0 {
+		return fmt.Errorf("the footman must be available from the start")
+	}
+	return nil
+}
+
+// Stats returns the stats of the unit kind, or nil when it is not in the
+// roster.
+func (r *Roster) Stats(id string) *UnitStats {
+	for i := range r.Units {
+		if r.Units[i].ID == id {
+			return &r.Units[i]
+		}
+	}
+	return nil
+}
+
+// UnlockedAt returns the units unlocked by the given Barracks letter stage.
+func (r *Roster) UnlockedAt(stage int) []UnitStats {
+	var out []UnitStats
+	for _, u := range r.Units {
+		if stage > 0 && u.Stage == stage {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// SetRoster replaces the unit stats table used by the Barracks.
+func (g *Game) SetRoster(r *Roster) {
+	if g.barracks != nil {
+		g.barracks.SetRoster(r)
+	}
+}
+
+// barracksLine is the HUD line naming the unit the Barracks trains next,
+// e.g. "Barracks: Archer (:train footman|archer)". It is empty until a
+// second unit is unlocked.
+func (g *Game) barracksLine() string {
+	if g.barracks == nil {
+		return ""
+	}
+	units := g.barracks.Trainable()
+	if len(units) < 2 {
+		return ""
+	}
+	name := g.barracks.Selected()
+	if s := g.barracks.Roster().Stats(name); s != nil && s.Name != "" {
+		name = s.Name
+	}
+	if cost := g.barracks.TrainingCost(); !cost.IsZero() {
+		name += " " + cost.String()
+	}
+	return fmt.Sprintf("Barracks: %s (:train %s)", name, strings.Join(units, "|"))
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRosterFileMatchesDefault(t *testing.T) {
+	r, err := LoadRoster("../../" + UnitsFile)
+	if err != nil {
+		t.Fatalf("load %s: %v", UnitsFile, err)
+	}
+	if !reflect.DeepEqual(r, DefaultRoster()) {
+		t.Errorf("%s should mirror DefaultRoster", UnitsFile)
+	}
+}
+
+func TestRosterValidation(t *testing.T) {
+	bad := map[string]string{
+		"unknown id":      "units:\n  - {id: footman, hp: 10}\n  - {id: dragon, hp: 5}\n",
+		"duplicate":       "units:\n  - {id: footman, hp: 10}\n  - {id: footman, hp: 5}\n",
+		"no hp":           "units:\n  - {id: footman}\n",
+		"ranged no range": "units:\n  - {id: footman, hp: 10}\n  - {id: archer, hp: 5, interval: 1}\n",
+		"locked footman":  "units:\n  - {id: footman, hp: 10, stage: 1}\n",
+		"no such stage":   "units:\n  - {id: footman, hp: 10}\n  - {id: knight, hp: 5, stage: 99}\n",
+		"no footman":      "units:\n  - {id: knight, hp: 10}\n",
+		"unknown field":   "units:\n  - {id: footman, hp: 10, mana: 3}\n",
+	}
+	for name, data := range bad {
+		if _, err := ParseRoster([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBarracksUnitSelection(t *testing.T) {
+	b := NewBarracks()
+	if b.Select(UnitArcher) {
+		t.Fatalf("archers should be locked at the start")
+	}
+	var pool ResourcePool
+	pool.AddKingsPoints(100)
+	if !b.UnlockNext(&pool) || b.Select(UnitArcher) {
+		t.Fatalf("the first letter stage should not unlock archers")
+	}
+	if got := b.NextUnits(); len(got) != 1 || got[0].ID != UnitArcher {
+		t.Fatalf("the second letter stage should unlock the archer, got %v", got)
+	}
+	if !b.UnlockNext(&pool) || !b.Select(UnitArcher) {
+		t.Fatalf("unlocked archers should be selectable")
+	}
+	b.pendingWord = "fjfj"
+	if _, ok := b.OnWordCompleted("fjfj").(*Archer); !ok {
+		t.Errorf("the next word should train the selected archer")
+	}
+	if !reflect.DeepEqual(b.Trainable(), []string{UnitFootman, UnitArcher}) {
+		t.Errorf("unexpected trainable units %v", b.Trainable())
+	}
+}
+
+func TestBarracksBonusAppliesToRoster(t *testing.T) {
+	b := NewBarracks()
+	b.SetFootmanBonus(5, 1)
+	k := b.train(UnitKnight, Point{}).body()
+	if k.hp != 25 || k.maxHP != 25 || k.damage != 3 {
+		t.Errorf("knight should get the training bonus, got %d/%d hp %d damage", k.hp, k.maxHP, k.damage)
+	}
+	h := b.train(UnitHealer, Point{}).body()
+	if h.damage != 0 {
+		t.Errorf("healers should not gain damage, got %d", h.damage)
+	}
+}
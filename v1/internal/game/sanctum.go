@@ -0,0 +1,226 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+const (
+	sanctumSource  = "Sanctum"
+	magicFamily    = "Magic"
+	sanctumMana    = 1  // Mana gathered every interval
+	sanctumMaxMana = 20 // Mana the Sanctum stores at most
+)
+
+// Sanctum is a Magic building. It gathers Mana over time and, while it can
+// pay for the selected spell, offers that spell's word; typing the word
+// casts the spell.
+type Sanctum struct {
+	timer        CooldownTimer // cooldown between spell words
+	manaTimer    CooldownTimer // cooldown between Mana gains
+	letterPool   []rune        // available letters for word generation
+	wordLenMin   int
+	wordLenMax   int
+	pendingWord  string // spell word currently in queue (if any)
+	pendingSpell string // spell the pending word casts
+	active       bool
+	queue        *QueueManager
+	book         *SpellBook
+	selected     string // spell the next word prepares
+}
+
+// NewSanctum creates a Sanctum with the starting spell book.
+func NewSanctum() *Sanctum {
+	return &Sanctum{
+		timer:      NewCooldownTimer(8.0),
+		manaTimer:  NewCooldownTimer(4.0),
+		letterPool: []rune{'f', 'j'},
+		wordLenMin: 4,
+		wordLenMax: 6,
+		active:     true,
+		book:       NewSpellBook(),
+		selected:   SpellHeal,
+	}
+}
+
+// Update gathers Mana into the pool and, once the cooldown is up, spends the
+// selected spell's Mana to offer its word, which is returned.
+func (s *Sanctum) Update(dt float64, pool *ResourcePool) string {
+	if !s.active || pool == nil {
+		return ""
+	}
+	if s.manaTimer.Tick(dt) {
+		s.manaTimer.Reset()
+		if pool.ManaAmount() < sanctumMaxMana {
+			pool.AddMana(sanctumMana)
+		}
+	}
+	if s.pendingWord != "" || !s.timer.Tick(dt) || !pool.Spend(s.book.Cost(s.selected)) {
+		return ""
+	}
+	s.pendingWord = s.generateWord()
+	s.pendingSpell = s.selected
+	if s.queue != nil {
+		s.queue.Enqueue(Word{Text: s.pendingWord, Source: sanctumSource, Family: magicFamily})
+	}
+	return s.pendingWord
+}
+
+// generateWord creates a random word from the Sanctum letter pool.
+func (s *Sanctum) generateWord() string {
+	length := s.wordLenMin
+	if s.wordLenMax > s.wordLenMin {
+		length += rand.Intn(s.wordLenMax - s.wordLenMin + 1)
+	}
+	word := make([]rune, length)
+	for i := range word {
+		word[i] = s.letterPool[rand.Intn(len(s.letterPool))]
+	}
+	return string(word)
+}
+
+// OnWordCompleted returns the spell cast by the word, or "" if it is not the
+// pending spell word.
+func (s *Sanctum) OnWordCompleted(word string) string {
+	if word == "" || word != s.pendingWord {
+		return ""
+	}
+	spell := s.pendingSpell
+	s.pendingWord, s.pendingSpell = "", ""
+	s.timer.Reset()
+	return spell
+}
+
+// Select picks the spell the next word prepares. Unknown spells are refused.
+func (s *Sanctum) Select(id string) bool {
+	if s.book.Rank(id) == 0 {
+		return false
+	}
+	s.selected = id
+	return true
+}
+
+// Selected returns the spell the next word prepares.
+func (s *Sanctum) Selected() string { return s.selected }
+
+// Book returns the Sanctum's spell book.
+func (s *Sanctum) Book() *SpellBook { return s.book }
+
+// SetLetterPool updates the Sanctum letter pool.
+func (s *Sanctum) SetLetterPool(pool []rune) { s.letterPool = pool }
+
+// SetQueue assigns a QueueManager for global word management.
+func (s *Sanctum) SetQueue(q *QueueManager) { s.queue = q }
+
+// SetActive enables or disables the Sanctum.
+func (s *Sanctum) SetActive(active bool) { s.active = active }
+
+// SetCooldown sets the remaining cooldown directly (for testing).
+func (s *Sanctum) SetCooldown(c float64) { s.timer.remaining = c }
+
+// castSpell triggers the spell's effect at its current rank.
+func (g *Game) castSpell(id string) {
+	if g.sanctum == nil || id == "" {
+		return
+	}
+	power := g.sanctum.book.Power(id)
+	switch id {
+	case SpellHeal:
+		g.base.Heal(int(power))
+	case SpellFreeze:
+		g.freezeTimer = math.Max(g.freezeTimer, power)
+	case SpellStrike:
+		g.strikeLane(int(power), g.sanctum.book.Spell(id).Radius)
+	}
+}
+
+// frozen reports whether a Freeze spell is holding the enemies in place.
+func (g *Game) frozen() bool { return g.freezeTimer > 0 }
+
+// lanePaths returns the route of every map lane, from its spawn tile through
+// its waypoints to the base.
+func (g *Game) lanePaths() [][]Point {
+	var paths [][]Point
+	if g.gameMap == nil || g.base == nil {
+		return nil
+	}
+	for _, l := range g.gameMap.Lanes {
+		var path []Point
+		for _, w := range l.Waypoints {
+			path = append(path, tileCenter(w))
+		}
+		paths = append(paths, append(path, g.base.pos))
+	}
+	return paths
+}
+
+// distanceToPath returns how far p is from the nearest segment of the path.
+func distanceToPath(p Point, path []Point) float64 {
+	best := math.Inf(1)
+	for i := 0; i+1 < len(path); i++ {
+		a, b := path[i], path[i+1]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		t := 0.0
+		if l := dx*dx + dy*dy; l > 0 {
+			t = math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/l))
+		}
+		best = math.Min(best, math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy)))
+	}
+	return best
+}
+
+// strikeLane damages every enemy within radius of the lane holding the most
+// enemies. Without lanes the strike runs along the row of the enemy closest
+// to the base.
+func (g *Game) strikeLane(damage int, radius float64) {
+	paths := g.lanePaths()
+	if len(paths) == 0 {
+		nearest := math.Inf(1)
+		for _, e := range g.mobs {
+			x, y := e.Position()
+			if d := math.Hypot(x-g.base.pos.X, y-g.base.pos.Y); e.Alive() && d < nearest {
+				nearest = d
+				paths = [][]Point{{{0, y}, {float64(gridCols * TileSize), y}}}
+			}
+		}
+	}
+	var best []Enemy
+	for _, path := range paths {
+		var hit []Enemy
+		for _, e := range g.mobs {
+			if x, y := e.Position(); e.Alive() && distanceToPath(Point{x, y}, path) <= radius {
+				hit = append(hit, e)
+			}
+		}
+		if len(hit) > len(best) {
+			best = hit
+		}
+	}
+	for _, e := range best {
+		e.Damage(damage)
+	}
+}
+
+// sanctumLine is the HUD line for the Sanctum: the spell being prepared, the
+// known spells and any active Freeze.
+func (g *Game) sanctumLine() string {
+	s := g.sanctum
+	if s == nil {
+		return ""
+	}
+	book := s.book
+	def := book.Spell(s.selected)
+	line := fmt.Sprintf("Sanctum: %s rank %d, %s", def.Name, book.Rank(s.selected), book.Cost(s.selected))
+	if s.pendingWord != "" {
+		line = fmt.Sprintf("Sanctum: type %s to cast %s", s.pendingWord, book.Spell(s.pendingSpell).Name)
+	}
+	if known := book.Known(); len(known) > 1 {
+		line += fmt.Sprintf(" (:spell %s)", strings.Join(known, "|"))
+	}
+	if g.frozen() {
+		line += fmt.Sprintf(" | Frozen %.0fs", math.Ceil(g.freezeTimer))
+	}
+	return line
+}
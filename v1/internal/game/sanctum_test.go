@@ -0,0 +1,96 @@
+package game
+
+import "testing"
+
+func TestSanctumSpendsManaForSpellWords(t *testing.T) {
+	s := NewSanctum()
+	q := NewQueueManager()
+	s.SetQueue(q)
+	var pool ResourcePool
+	s.SetCooldown(0)
+	if w := s.Update(0.1, &pool); w != "" {
+		t.Fatalf("no word should be offered without Mana, got %q", w)
+	}
+	pool.AddMana(5)
+	w := s.Update(0.1, &pool)
+	if w == "" || pool.ManaAmount() != 2 {
+		t.Fatalf("heal should cost 3 Mana, word %q mana %d", w, pool.ManaAmount())
+	}
+	if dq, ok := q.Peek(); !ok || dq.Source != sanctumSource || dq.Family != magicFamily {
+		t.Errorf("expected a Magic word in the queue, got %+v", dq)
+	}
+	if s.OnWordCompleted("nope") != "" {
+		t.Errorf("other words must not cast")
+	}
+	if s.OnWordCompleted(w) != SpellHeal {
+		t.Errorf("the spell word should cast heal")
+	}
+}
+
+func TestSanctumGathersMana(t *testing.T) {
+	s := NewSanctum()
+	var pool ResourcePool
+	pool.AddMana(sanctumMaxMana)
+	s.manaTimer.remaining = 0
+	s.Update(0.1, &pool)
+	if pool.ManaAmount() != sanctumMaxMana {
+		t.Errorf("Mana should stop at %d, got %d", sanctumMaxMana, pool.ManaAmount())
+	}
+	pool.Mana.Set(0)
+	s.manaTimer.remaining = 0
+	s.Update(0.1, &pool)
+	if pool.ManaAmount() != sanctumMana {
+		t.Errorf("expected %d Mana, got %d", sanctumMana, pool.ManaAmount())
+	}
+}
+
+func TestSanctumSelect(t *testing.T) {
+	s := NewSanctum()
+	if s.Select(SpellFreeze) {
+		t.Fatalf("freeze is not known yet")
+	}
+	s.Book().Study("Index Extensions")
+	if !s.Select(SpellFreeze) || s.Selected() != SpellFreeze {
+		t.Errorf("freeze should be selectable once learned")
+	}
+}
+
+func TestCastHealAndFreeze(t *testing.T) {
+	g := &Game{base: NewBase(64, 556, 10), sanctum: NewSanctum()}
+	g.base.Damage(5)
+	g.castSpell(SpellHeal)
+	if g.base.Health() != 7 {
+		t.Errorf("heal should restore 2 health, got %d", g.base.Health())
+	}
+	g.sanctum.Book().Study("Index Extensions")
+	g.castSpell(SpellFreeze)
+	if !g.frozen() || g.freezeTimer != 3 {
+		t.Errorf("freeze should last 3s, got %v", g.freezeTimer)
+	}
+}
+
+func TestStrikeHitsBusiestLane(t *testing.T) {
+	g := &Game{base: NewBase(64, 556, 10), gameMap: DefaultMap()}
+	north, center := tileCenter([2]int{50, 6}), tileCenter([2]int{50, 16})
+	a := NewMob(north.X, north.Y, g.base, 5, 1)
+	b := NewMob(north.X+20, north.Y, g.base, 5, 1)
+	c := NewMob(center.X, center.Y, g.base, 5, 1)
+	g.mobs = []Enemy{a, b, c}
+	g.strikeLane(3, 40)
+	if a.health != 2 || b.health != 2 {
+		t.Errorf("north lane mobs should be struck, got %d and %d", a.health, b.health)
+	}
+	if c.health != 5 {
+		t.Errorf("center lane mob should be spared, got %d", c.health)
+	}
+}
+
+func TestDistanceToPath(t *testing.T) {
+	path := []Point{{0, 0}, {100, 0}}
+	if d := distanceToPath(Point{50, 30}, path); d != 30 {
+		t.Errorf("expected 30, got %v", d)
+	}
+	if d := distanceToPath(Point{130, 40}, path); d != 50 {
+		t.Errorf("expected 50 past the end, got %v", d)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -60,3 +61,24 @@ func TestSetSaveSlotChangesPath(t *testing.T) {
 		t.Fatalf("unexpected path %s", g.currentSavePath())
 	}
 }
+
+func TestSaveKeepsBarracksUnits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.json")
+	g := NewGame()
+	g.resources.AddKingsPoints(100)
+	g.barracks.UnlockNext(&g.resources)
+	g.barracks.UnlockNext(&g.resources)
+	g.barracks.Select(UnitArcher)
+	g.saveGame(path)
+	l := NewGame()
+	if err := l.loadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	b := l.barracks
+	if b.Stage() != 2 || len(b.letterPool) != len(g.barracks.letterPool) {
+		t.Errorf("loaded Barracks at stage %d with %d letters, want 2 and %d", b.Stage(), len(b.letterPool), len(g.barracks.letterPool))
+	}
+	if !reflect.DeepEqual(b.Trainable(), []string{UnitFootman, UnitArcher}) || b.Selected() != UnitArcher {
+		t.Errorf("loaded units %v with %s selected, want footman and archer with archer", b.Trainable(), b.Selected())
+	}
+}
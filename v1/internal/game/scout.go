@@ -0,0 +1,139 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	spawnWarning   = 3.0  // seconds of warning shown before a spawn
+	scoutAccuracy  = 0.9  // accuracy each Scouting level needs to see a wave further
+	scoutAccuracy2 = 0.97 // accuracy for the second extra wave, from Far Scouting
+	scoutCountdown = 10.0 // seconds before an Endless wave its preview shows
+)
+
+// showWavePreview reports whether the coming waves are listed: while the shop
+// is open, or during the last seconds before the next Endless wave.
+func (g *Game) showWavePreview() bool {
+	return g.shopOpen || g.endless() && endlessWaveTime-g.waveTimer <= scoutCountdown
+}
+
+// scoutDepth returns how many upcoming waves the preview shows. The next wave
+// is always visible; each Scouting skill level reveals one more wave while the
+// player's accuracy stays high enough.
+func (g *Game) scoutDepth() int {
+	depth := 1
+	acc := g.typing.Accuracy()
+	for lvl, need := range []float64{scoutAccuracy, scoutAccuracy2} {
+		if lvl < g.scouting && acc >= need {
+			depth++
+		}
+	}
+	return depth
+}
+
+// mobName returns the wave script name of a mob type.
+func mobName(kind MobType) string {
+	for name, k := range mobKinds {
+		if k == kind {
+			return name
+		}
+	}
+	return ""
+}
+
+// groupSummary describes a wave group for the preview, e.g. "4x grunt (north)".
+// Mixed groups list the mob types they pick from.
+func (g *Game) groupSummary(grp WaveGroup, loops int) string {
+	out := fmt.Sprintf("%dx %s", grp.Count+grp.LoopGrowth*loops, grp.Mob)
+	if grp.Mob == mobMixed {
+		names := make([]string, len(mixedMobs))
+		for i, k := range mixedMobs {
+			names[i] = mobName(k)
+		}
+		out += " " + strings.Join(names, "/")
+	}
+	if grp.Mob == "boss" {
+		if def, ok := g.bosses.Boss(grp.Boss); ok {
+			out += " " + def.Name
+		}
+	}
+	if grp.Lane != "" {
+		out += " (" + grp.Lane + ")"
+	}
+	return out
+}
+
+// wavePreview lists the composition of wave n.
+func (g *Game) wavePreview(n int) []string {
+	if g.waveScript == nil {
+		return nil
+	}
+	def, loops := g.waveScript.Wave(n)
+	title := fmt.Sprintf("Wave %d", n)
+	if def.Name != "" && def.Name != title {
+		title += ": " + def.Name
+	}
+	lines := []string{title}
+	for _, grp := range def.Groups {
+		lines = append(lines, "  "+g.groupSummary(grp, loops))
+	}
+	return lines
+}
+
+// scoutingLines returns the preview of every wave the player can scout after
+// the current one.
+func (g *Game) scoutingLines() []string {
+	lines := []string{"-- SCOUTING --"}
+	depth := g.scoutDepth()
+	for i := 1; i <= depth; i++ {
+		lines = append(lines, g.wavePreview(g.currentWave+i)...)
+	}
+	if g.scouting > 0 {
+		lines = append(lines, fmt.Sprintf("Accuracy %.0f%%: %d wave(s) ahead", g.typing.Accuracy()*100, depth))
+	}
+	return lines
+}
+
+// upcomingSpawn is a pending spawn due within the warning window.
+type upcomingSpawn struct {
+	spawn *waveSpawn
+	eta   float64 // seconds until it spawns
+}
+
+// upcomingSpawns returns the pending spawns due within the warning window.
+func (g *Game) upcomingSpawns() []upcomingSpawn {
+	var out []upcomingSpawn
+	eta := -g.spawnTicker
+	for i := range g.pendingSpawns {
+		s := &g.pendingSpawns[i]
+		eta += s.interval(g.spawnInterval)
+		if eta > spawnWarning {
+			break
+		}
+		out = append(out, upcomingSpawn{spawn: s, eta: eta})
+	}
+	return out
+}
+
+// announceSpawns fixes the entry tile of every spawn due soon so its
+// indicator shows where the mob will appear.
+func (g *Game) announceSpawns() {
+	for _, u := range g.upcomingSpawns() {
+		if !u.spawn.hasEntry {
+			u.spawn.entry = g.entryTile(u.spawn.group)
+			u.spawn.hasEntry = true
+		}
+	}
+}
+
+// spawnIndicatorLabel names the mobs arriving on one tile, e.g. "grunt x2 1.5s".
+func spawnIndicatorLabel(mob string, count int, eta float64) string {
+	var sb strings.Builder
+	sb.WriteString(mob)
+	if count > 1 {
+		fmt.Fprintf(&sb, " x%d", count)
+	}
+	fmt.Fprintf(&sb, " %.1fs", eta)
+	return sb.String()
+}
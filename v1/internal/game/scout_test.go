@@ -0,0 +1,117 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScoutDepthNeedsSkillAndAccuracy(t *testing.T) {
+	g := &Game{typing: NewTypingStats()}
+	for i := 0; i < 10; i++ {
+		g.typing.Record(true)
+	}
+	if d := g.scoutDepth(); d != 1 {
+		t.Fatalf("without Scouting only the next wave is visible, got %d", d)
+	}
+	g.scouting = 2
+	if d := g.scoutDepth(); d != 3 {
+		t.Errorf("perfect accuracy with two levels should show 3 waves, got %d", d)
+	}
+	for i := 0; i < 10; i++ {
+		g.typing.Record(false)
+	}
+	if d := g.scoutDepth(); d != 1 {
+		t.Errorf("poor accuracy should hide extra waves, got %d", d)
+	}
+}
+
+func TestWavePreviewListsGroups(t *testing.T) {
+	data := "waves:\n  - name: Siege\n    groups:\n      - {mob: grunt, count: 3, lane: north}\n      - {mob: boss, count: 1, boss: warlord}\n"
+	s, err := ParseWaveScript([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Game{cfg: &DefaultConfig, waveScript: s, bosses: DefaultBosses()}
+	got := strings.Join(g.wavePreview(1), "|")
+	want := "Wave 1: Siege|  3x grunt (north)|  1x boss Orc Warlord"
+	if got != want {
+		t.Errorf("preview mismatch:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestWavePreviewListsMixedMobs(t *testing.T) {
+	data := "waves:\n  - groups:\n      - {mob: mixed, count: 4}\n"
+	s, err := ParseWaveScript([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Game{cfg: &DefaultConfig, waveScript: s, bosses: DefaultBosses()}
+	if got := g.wavePreview(1)[1]; got != "  4x mixed basic/armored/fast/shielded" {
+		t.Errorf("mixed groups should list their mob types, got %q", got)
+	}
+}
+
+func TestWavePreviewShowsInEndlessCountdown(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, mode: ModeEndless}
+	if g.showWavePreview() {
+		t.Fatalf("preview should wait for the countdown")
+	}
+	g.waveTimer = endlessWaveTime - scoutCountdown
+	if !g.showWavePreview() {
+		t.Errorf("preview should show during the Endless countdown")
+	}
+	g.mode = ModeClassic
+	if g.showWavePreview() {
+		t.Errorf("classic waves only preview in the shop")
+	}
+}
+
+func TestSpawnsAnnouncedBeforeArrival(t *testing.T) {
+	data := "waves:\n  - groups:\n      - {mob: basic, count: 1, spacing: 5}\n"
+	s, err := ParseWaveScript([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Game{cfg: &DefaultConfig, currentWave: 1, base: NewBase(64, 556, 10), gameMap: DefaultMap(), pathVersion: 1}
+	g.SetWaveScript(s)
+	g.updateSpawns(1)
+	if g.pendingSpawns[0].hasEntry {
+		t.Fatalf("spawn 4s away should not be announced yet")
+	}
+	g.updateSpawns(1.5)
+	up := g.upcomingSpawns()
+	if len(up) != 1 || !up[0].spawn.hasEntry {
+		t.Fatalf("spawn within %.0fs should be announced", spawnWarning)
+	}
+	entry := up[0].spawn.entry
+	g.updateSpawns(2.5)
+	if len(g.mobs) != 1 {
+		t.Fatalf("mob should have spawned")
+	}
+	x, y := g.mobs[0].Position()
+	if c := tileCenter(entry); x != c.X || y != c.Y {
+		t.Errorf("mob should enter on the announced tile %v", entry)
+	}
+}
+
+func TestScoutingSkillHasTwoRanks(t *testing.T) {
+	tree, err := SampleSkillTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := &ResourcePool{}
+	pool.AddKingsPoints(30)
+	g := &Game{typing: NewTypingStats()}
+	if tree.Unlock("far_scouting", pool) {
+		t.Fatalf("Far Scouting should need Scouting first")
+	}
+	for _, id := range []string{"scouting", "far_scouting"} {
+		if !tree.Unlock(id, pool) {
+			t.Fatalf("%s should unlock", id)
+		}
+		g.applySkillEffects(tree.Nodes[id])
+	}
+	if g.scouting != 2 {
+		t.Errorf("both ranks should give Scouting level 2, got %d", g.scouting)
+	}
+}
@@ -0,0 +1,176 @@
+package game
+
+import "math"
+
+const (
+	towerBaseHP      = 10 // tower hit points at level 1
+	towerHPPerLevel  = 5  // extra hit points per tower level
+	repairWordLength = 5
+	repairWordSource = "Tower"
+	repairWordFamily = "Repair"
+)
+
+// Kinds of target a ranged enemy can prefer.
+const (
+	siegeTargetTowers = iota
+	siegeTargetFootmen
+	siegeTargetBuildings
+	siegeTargetBase
+)
+
+// rangedProfile describes how a ranged enemy type fights.
+type rangedProfile struct {
+	reach    float64 // pixels from which the enemy stops to attack
+	damage   int
+	interval float64 // seconds between attacks
+	targets  []int   // target kinds in order of preference
+}
+
+// rangedProfiles holds the stats of every ranged enemy type. Archers pick off
+// Footmen, shamans hex towers and catapults bombard towers, production
+// buildings and the base from long range.
+var rangedProfiles = map[MobType]rangedProfile{
+	MobArcher:   {reach: 160, damage: 1, interval: 1.5, targets: []int{siegeTargetFootmen, siegeTargetTowers}},
+	MobShaman:   {reach: 200, damage: 2, interval: 2.5, targets: []int{siegeTargetTowers, siegeTargetFootmen}},
+	MobCatapult: {reach: 320, damage: 4, interval: 5, targets: []int{siegeTargetTowers, siegeTargetBuildings, siegeTargetBase}},
+}
+
+// rangedAttack is the attack state of a ranged enemy.
+type rangedAttack struct {
+	rangedProfile
+	timer   CooldownTimer
+	holding bool // a target is in reach so the enemy stands still
+}
+
+// NewRangedMob creates an enemy of a ranged type that stops to attack
+// defenses within reach.
+func NewRangedMob(kind MobType, x, y float64, target *Base, hp int, speed float64) *Mob {
+	m := NewMob(x, y, target, hp, speed)
+	m.mobType = kind
+	if p, ok := rangedProfiles[kind]; ok {
+		m.attack = &rangedAttack{rangedProfile: p, timer: NewCooldownTimer(p.interval)}
+	}
+	return m
+}
+
+// siegeTarget is anything a ranged enemy can shoot at.
+type siegeTarget interface {
+	Position() (x, y float64)
+	Alive() bool
+}
+
+// updateRangedAttacks lets every ranged enemy pick the preferred target in
+// reach, hold its ground and strike on its interval.
+func (g *Game) updateRangedAttacks(dt float64) {
+	for _, e := range g.mobs {
+		m, ok := asMob(e)
+		if !ok || m.attack == nil || !m.Alive() {
+			continue
+		}
+		target := g.siegeTargetFor(m)
+		m.attack.holding = target != nil
+		if target == nil {
+			continue
+		}
+		if m.attack.timer.Tick(dt) {
+			g.strike(target, m.attack.damage)
+			m.attack.timer.Reset()
+		}
+	}
+}
+
+// siegeTargetFor returns the nearest target of the most preferred kind within
+// the enemy's reach, or nil.
+func (g *Game) siegeTargetFor(m *Mob) siegeTarget {
+	for _, kind := range m.attack.targets {
+		var best siegeTarget
+		bestDist := m.attack.reach
+		consider := func(t siegeTarget) {
+			if !t.Alive() {
+				return
+			}
+			x, y := t.Position()
+			if d := math.Hypot(x-m.pos.X, y-m.pos.Y); d <= bestDist {
+				best, bestDist = t, d
+			}
+		}
+		switch kind {
+		case siegeTargetTowers:
+			for _, t := range g.towers {
+				consider(t)
+			}
+		case siegeTargetFootmen:
+			if g.military != nil {
+				for _, u := range g.military.Units() {
+					consider(u)
+				}
+			}
+		case siegeTargetBuildings:
+			for _, b := range g.buildings {
+				consider(b)
+			}
+		case siegeTargetBase:
+			if g.base != nil {
+				consider(g.base)
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// strike applies ranged damage to a target.
+func (g *Game) strike(t siegeTarget, damage int) {
+	switch v := t.(type) {
+	case *Tower:
+		g.damageTower(v, damage)
+	case *Building:
+		g.damageBuilding(v, damage)
+	case Unit:
+		v.Damage(damage)
+	case *Base:
+		v.Damage(damage)
+	}
+}
+
+// damageTower hurts a tower, queues its repair word on the first hit and
+// destroys it when its health runs out.
+func (g *Game) damageTower(t *Tower, damage int) {
+	t.Damage(damage)
+	if !t.Alive() {
+		for i, tt := range g.towers {
+			if tt == t {
+				g.removeTower(i)
+				break
+			}
+		}
+		return
+	}
+	if t.repairWord == "" && g.queue != nil {
+		t.repairWord = g.newRepairWord()
+		g.queue.Enqueue(Word{Text: t.repairWord, Source: repairWordSource, Family: repairWordFamily})
+	}
+}
+
+// newRepairWord returns a random repair word from the unlocked letters.
+func (g *Game) newRepairWord() string {
+	word := make([]rune, repairWordLength)
+	for i := range word {
+		word[i] = g.randomReloadLetter()
+	}
+	return string(word)
+}
+
+// repairTower restores the damaged tower waiting on the completed repair
+// word to full health.
+func (g *Game) repairTower(word string) {
+	for _, t := range g.towers {
+		if t.repairWord == word {
+			t.hp = t.maxHP
+			t.repairWord = ""
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package game
+
+import "testing"
+
+func TestRangedMobHoldsAndStrikesTower(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), queue: NewQueueManager(), military: NewMilitary()}
+	tower := NewTower(g, 400, 300)
+	g.towers = []*Tower{tower}
+	m := NewRangedMob(MobShaman, 550, 300, g.base, 5, 10)
+	g.mobs = []Enemy{m}
+	g.updateRangedAttacks(rangedProfiles[MobShaman].interval)
+	if !m.attack.holding {
+		t.Fatalf("shaman should stop with a tower in reach")
+	}
+	m.Update(1)
+	if x, _ := m.Position(); x != 550 {
+		t.Errorf("holding shaman should not move, x=%.1f", x)
+	}
+	if hp, max := tower.Health(); hp != max-rangedProfiles[MobShaman].damage {
+		t.Errorf("tower should take one hit, hp=%d/%d", hp, max)
+	}
+}
+
+func TestArcherPrefersFootmen(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), queue: NewQueueManager(), military: NewMilitary()}
+	tower := NewTower(g, 400, 300)
+	g.towers = []*Tower{tower}
+	f := NewFootman(450, 300)
+	f.speed = 0
+	g.military.AddUnit(f)
+	m := NewRangedMob(MobArcher, 500, 300, g.base, 5, 10)
+	if g.siegeTargetFor(m) != f {
+		t.Fatalf("archer should target the footman before the tower")
+	}
+	f.alive = false
+	if g.siegeTargetFor(m) != tower {
+		t.Errorf("archer should fall back to the tower")
+	}
+}
+
+func TestDamagedTowerQueuesRepairWord(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), queue: NewQueueManager(), military: NewMilitary()}
+	tower := NewTower(g, 400, 300)
+	g.towers = []*Tower{tower}
+	g.damageTower(tower, 3)
+	w, ok := g.queue.Peek()
+	if !ok || w.Source != repairWordSource || w.Text != tower.repairWord {
+		t.Fatalf("expected a repair word in the queue, got %+v", w)
+	}
+	g.damageTower(tower, 1)
+	if g.queue.Len() != 1 {
+		t.Errorf("only one repair word should be queued per tower")
+	}
+	g.repairTower(w.Text)
+	if hp, max := tower.Health(); hp != max || tower.repairWord != "" {
+		t.Errorf("repair should restore full health, hp=%d/%d", hp, max)
+	}
+}
+
+func TestTowerDestroyedAtZeroHealth(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), queue: NewQueueManager(), military: NewMilitary()}
+	tower := NewTower(g, 400, 300)
+	g.towers = []*Tower{tower}
+	g.damageTower(tower, 1)
+	_, max := tower.Health()
+	g.damageTower(tower, max)
+	if len(g.towers) != 0 {
+		t.Fatalf("destroyed tower should be removed")
+	}
+	if g.queue.Len() != 0 {
+		t.Errorf("destroyed tower's repair word should leave the queue")
+	}
+}
+
+func TestCatapultWrecksBuilding(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, base: NewBase(64, 556, 10), queue: NewQueueManager()}
+	g.buildings = newBuildings(g.base)
+	farm := g.buildings[0]
+	m := NewRangedMob(MobCatapult, farm.pos.X+200, farm.pos.Y, g.base, 5, 10)
+	if g.siegeTargetFor(m) != farm {
+		t.Fatalf("catapult should bombard the nearest building before the base")
+	}
+	g.strike(farm, buildingHP)
+	if !g.silenced("Farmer") || g.silenced("Miner") {
+		t.Fatalf("only the wrecked Farmer should stop working")
+	}
+	w, ok := g.queue.Peek()
+	if !ok || w.Source != buildingRepairSource {
+		t.Fatalf("expected a building repair word in the queue, got %+v", w)
+	}
+	g.repairBuilding(w.Text)
+	if g.silenced("Farmer") || farm.hp != buildingHP {
+		t.Errorf("repair should bring the Farmer back, hp %d", farm.hp)
+	}
+}
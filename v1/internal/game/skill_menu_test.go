@@ -97,7 +97,7 @@ func TestSkillUnlockAppliesEffect(t *testing.T) {
 	if g.resources.KingsAmount() != 40 {
 		t.Fatalf("Kings Points not deducted")
 	}
-	if g.towerMods.DamageMult <= 1.0 {
+	if g.skillMods.DamageMult <= 1.0 {
 		t.Fatalf("damage multiplier not applied")
 	}
 }
@@ -29,7 +29,7 @@ func TestSkillStatePersistence(t *testing.T) {
 	if !ng.unlockedSkills["sharp_arrows"] {
 		t.Fatalf("skill not loaded")
 	}
-	if ng.towerMods.DamageMult != 1.1 {
+	if ng.skillMods.DamageMult != 1.1 {
 		t.Fatalf("modifier not restored")
 	}
 	if _, err := os.Stat(path); err != nil {
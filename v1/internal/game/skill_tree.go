@@ -11,6 +11,7 @@ const (
 	SkillTyping
 	SkillAutomation
 	SkillUtility
+	SkillClass // branch of the chosen character class
 )
 
 // String returns a human readable label for the category.
@@ -26,6 +27,8 @@ func (c SkillCategory) String() string {
 		return "Automation"
 	case SkillUtility:
 		return "Utility"
+	case SkillClass:
+		return "Class"
 	default:
 		return "Unknown"
 	}
@@ -39,6 +42,7 @@ type SkillNode struct {
 	Cost     int
 	Effects  map[string]float64
 	Prereqs  []string
+	Class    string // class that may unlock the node, empty for everyone
 }
 
 // SkillTree holds all skill nodes keyed by ID.
@@ -46,6 +50,16 @@ type SkillTree struct {
 	Nodes    map[string]*SkillNode
 	order    []string
 	unlocked map[string]bool
+	class    string // class of the current run
+}
+
+// SetClass sets the class whose branch may be unlocked.
+func (t *SkillTree) SetClass(name string) { t.class = name }
+
+// Available reports whether the node belongs to everyone or to the current
+// class.
+func (t *SkillTree) Available(n *SkillNode) bool {
+	return n.Class == "" || n.Class == t.class
 }
 
 // NodesByCategory returns a slice of skill nodes belonging to the given category.
@@ -84,6 +98,9 @@ func (t *SkillTree) CanUnlock(id string, pool *ResourcePool) bool {
 	if t.unlocked != nil && t.unlocked[id] {
 		return false
 	}
+	if !t.Available(node) {
+		return false
+	}
 	for _, p := range node.Prereqs {
 		if t.unlocked == nil || !t.unlocked[p] {
 			return false
@@ -197,6 +214,55 @@ func SampleSkillTree() (*SkillTree, error) {
 			Cost:     5,
 			Effects:  map[string]float64{"hotkeys": 1},
 		},
+		{
+			ID:       "scouting",
+			Name:     "Scouting",
+			Category: SkillUtility,
+			Cost:     10,
+			Effects:  map[string]float64{"scouting": 1},
+		},
+		{
+			ID:       "far_scouting",
+			Name:     "Far Scouting",
+			Category: SkillUtility,
+			Cost:     20,
+			Effects:  map[string]float64{"scouting": 1},
+			Prereqs:  []string{"scouting"},
+		},
+		{
+			ID:       "shield_wall",
+			Name:     "Shield Wall",
+			Category: SkillClass,
+			Cost:     10,
+			Effects:  map[string]float64{"footman_hp": 5},
+			Class:    "Knight",
+		},
+		{
+			ID:       "war_drums",
+			Name:     "War Drums",
+			Category: SkillClass,
+			Cost:     20,
+			Effects:  map[string]float64{"ability_cooldown_mult": 0.7},
+			Prereqs:  []string{"shield_wall"},
+			Class:    "Knight",
+		},
+		{
+			ID:       "eagle_eye",
+			Name:     "Eagle Eye",
+			Category: SkillClass,
+			Cost:     10,
+			Effects:  map[string]float64{"range_mult": 1.1},
+			Class:    "Archer",
+		},
+		{
+			ID:       "quick_draw",
+			Name:     "Quick Draw",
+			Category: SkillClass,
+			Cost:     20,
+			Effects:  map[string]float64{"ability_cooldown_mult": 0.7},
+			Prereqs:  []string{"eagle_eye"},
+			Class:    "Archer",
+		},
 	}
 	tree := &SkillTree{Nodes: map[string]*SkillNode{}, unlocked: map[string]bool{}}
 	for i := range nodes {
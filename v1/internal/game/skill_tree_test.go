@@ -7,11 +7,11 @@ func TestSampleSkillTree(t *testing.T) {
 	if err != nil {
 		t.Fatalf("sample skill tree: %v", err)
 	}
-	if len(tree.Nodes) != 6 {
-		t.Fatalf("expected 6 nodes got %d", len(tree.Nodes))
+	if len(tree.Nodes) != 12 {
+		t.Fatalf("expected 12 nodes got %d", len(tree.Nodes))
 	}
 	order := tree.UnlockOrder()
-	if len(order) != 6 {
+	if len(order) != len(tree.Nodes) {
 		t.Fatalf("unexpected unlock order length %d", len(order))
 	}
 	// ensure prerequisite ordering
@@ -33,6 +33,18 @@ func TestSampleSkillTree(t *testing.T) {
 			}
 		}
 	}
+	// every node, class nodes included, follows its prerequisites
+	pos := map[string]int{}
+	for i, id := range order {
+		pos[id] = i
+	}
+	for id, n := range tree.Nodes {
+		for _, p := range n.Prereqs {
+			if pos[p] > pos[id] {
+				t.Errorf("%s should come after its prereq %s", id, p)
+			}
+		}
+	}
 }
 
 func TestSkillTreeCycleDetect(t *testing.T) {
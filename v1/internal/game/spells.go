@@ -0,0 +1,133 @@
+package game
+
+import "fmt"
+
+// Spell ids. Each is picked with ":spell <id>".
+const (
+	SpellHeal   = "heal"   // restores base health
+	SpellFreeze = "freeze" // stops every enemy for a few seconds
+	SpellStrike = "strike" // damages every enemy along the busiest lane
+)
+
+// SpellDef describes a spell in the spell book.
+type SpellDef struct {
+	ID       string
+	Name     string
+	Mana     int      // Mana spent when the Sanctum prepares the spell
+	Power    float64  // health healed, damage dealt or seconds frozen at rank 1
+	PerRank  float64  // power added by every rank after the first
+	Radius   float64  // pixels either side of the lane a strike reaches
+	Learn    string   // tech node that teaches the spell, empty when known from the start
+	Upgrades []string // tech nodes that each raise the spell a rank
+}
+
+// defaultSpells is the spell book's content. Healing is known from the
+// start; the tech tree teaches the rest and ranks every spell up.
+var defaultSpells = []SpellDef{
+	{ID: SpellHeal, Name: "Heal", Mana: 3, Power: 2, PerRank: 1, Upgrades: []string{"Middle Fingers", "Top Row Index"}},
+	{ID: SpellFreeze, Name: "Freeze", Mana: 6, Power: 3, PerRank: 1, Learn: "Index Extensions", Upgrades: []string{"Top Row Pinky", "Bottom Index"}},
+	{ID: SpellStrike, Name: "Strike", Mana: 5, Power: 3, PerRank: 2, Radius: 40, Learn: "Ring Finger", Upgrades: []string{"Top Row Outer", "Bottom Outer"}},
+}
+
+// SpellBook holds the spells the Sanctum can prepare and their ranks.
+type SpellBook struct {
+	spells  []SpellDef
+	known   map[string]bool
+	studied map[string]int // upgrade nodes studied per spell
+}
+
+// NewSpellBook returns a book knowing the spells that need no tech.
+func NewSpellBook() *SpellBook {
+	b := &SpellBook{spells: defaultSpells, known: map[string]bool{}, studied: map[string]int{}}
+	for _, s := range b.spells {
+		if s.Learn == "" {
+			b.known[s.ID] = true
+		}
+	}
+	return b
+}
+
+// Spell returns the definition of the spell, or nil.
+func (b *SpellBook) Spell(id string) *SpellDef {
+	for i := range b.spells {
+		if b.spells[i].ID == id {
+			return &b.spells[i]
+		}
+	}
+	return nil
+}
+
+// Rank returns the spell's rank, 0 when unknown.
+func (b *SpellBook) Rank(id string) int {
+	if !b.known[id] {
+		return 0
+	}
+	return 1 + b.studied[id]
+}
+
+// Known returns the ids of the known spells in book order.
+func (b *SpellBook) Known() []string {
+	var out []string
+	for _, s := range b.spells {
+		if b.known[s.ID] {
+			out = append(out, s.ID)
+		}
+	}
+	return out
+}
+
+// Power returns the spell's power at its current rank.
+func (b *SpellBook) Power(id string) float64 {
+	s := b.Spell(id)
+	if s == nil || !b.known[id] {
+		return 0
+	}
+	return s.Power + s.PerRank*float64(b.Rank(id)-1)
+}
+
+// Cost returns the Mana the spell takes to prepare.
+func (b *SpellBook) Cost(id string) Cost {
+	if s := b.Spell(id); s != nil {
+		return Cost{Mana: s.Mana}
+	}
+	return Cost{}
+}
+
+// Teaches describes what studying the tech node does to the book, e.g.
+// "learns Freeze" or "Heal rank 2".
+func (b *SpellBook) Teaches(tech string) []string {
+	var out []string
+	for _, s := range b.spells {
+		if tech == "" {
+			break
+		}
+		if s.Learn == tech {
+			out = append(out, "learns "+s.Name)
+		}
+		for i, u := range s.Upgrades {
+			if u == tech {
+				out = append(out, fmt.Sprintf("%s rank %d", s.Name, i+2))
+			}
+		}
+	}
+	return out
+}
+
+// Study applies a tech node: spells it teaches become known and spells it
+// upgrades rank up. Upgrades of spells not yet known are kept for when they
+// are learned.
+func (b *SpellBook) Study(tech string) {
+	if tech == "" {
+		return
+	}
+	for _, s := range b.spells {
+		if s.Learn == tech {
+			b.known[s.ID] = true
+		}
+		for _, u := range s.Upgrades {
+			if u == tech {
+				b.studied[s.ID]++
+			}
+		}
+	}
+}
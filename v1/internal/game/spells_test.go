@@ -0,0 +1,48 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpellBookStartsWithHeal(t *testing.T) {
+	b := NewSpellBook()
+	if got := b.Known(); !reflect.DeepEqual(got, []string{SpellHeal}) {
+		t.Fatalf("a new book should only know heal, got %v", got)
+	}
+	if b.Rank(SpellFreeze) != 0 || b.Power(SpellFreeze) != 0 {
+		t.Errorf("freeze should be unknown")
+	}
+	if b.Cost(SpellHeal) != (Cost{Mana: 3}) {
+		t.Errorf("heal cost %v", b.Cost(SpellHeal))
+	}
+}
+
+func TestSpellBookStudy(t *testing.T) {
+	b := NewSpellBook()
+	b.Study("Middle Fingers")
+	if b.Rank(SpellHeal) != 2 || b.Power(SpellHeal) != 3 {
+		t.Errorf("heal should reach rank 2, got rank %d power %v", b.Rank(SpellHeal), b.Power(SpellHeal))
+	}
+	b.Study("Top Row Pinky")
+	if b.Rank(SpellFreeze) != 0 {
+		t.Fatalf("an upgrade must not teach an unknown spell")
+	}
+	b.Study("Index Extensions")
+	if b.Rank(SpellFreeze) != 2 || b.Power(SpellFreeze) != 4 {
+		t.Errorf("earlier upgrades should count once freeze is learned, got rank %d", b.Rank(SpellFreeze))
+	}
+}
+
+func TestSpellBookTeaches(t *testing.T) {
+	b := NewSpellBook()
+	if got := b.Teaches("Ring Finger"); !reflect.DeepEqual(got, []string{"learns Strike"}) {
+		t.Errorf("Ring Finger teaches %v", got)
+	}
+	if got := b.Teaches("Top Row Index"); !reflect.DeepEqual(got, []string{"Heal rank 3"}) {
+		t.Errorf("Top Row Index teaches %v", got)
+	}
+	if got := b.Teaches("Home Row"); got != nil {
+		t.Errorf("Home Row should teach nothing, got %v", got)
+	}
+}
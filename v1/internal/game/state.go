@@ -10,6 +10,7 @@ const (
 	PhasePaused
 	PhaseGameOver
 	PhaseSettings
+	PhaseVictory
 )
 
 func (p GamePhase) String() string {
@@ -26,6 +27,8 @@ func (p GamePhase) String() string {
 		return "GameOver"
 	case PhaseSettings:
 		return "Settings"
+	case PhaseVictory:
+		return "Victory"
 	}
 	return "Unknown"
 }
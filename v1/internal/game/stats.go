@@ -0,0 +1,255 @@
+package game
+
+import "math"
+
+const (
+	baseForesight     = 5    // reload letters previewed before upgrades
+	maxForesight      = 10   // upper bound on previewed reload letters
+	minTowerRate      = 0.01 // fastest allowed seconds between shots
+	upgradeRangeStep  = 50   // range gained per shop range upgrade
+	upgradeRateFactor = 0.9  // fire interval multiplier per shop fire rate upgrade
+)
+
+// StatSource identifies a layer of the tower stat pipeline. Layers are applied
+// in declaration order, each on top of the result of the previous one.
+type StatSource int
+
+const (
+	SourceBase    StatSource = iota // configuration values
+	SourceType                      // tower type characteristics
+	SourceLevel                     // tower level
+	SourceShop                      // upgrades bought for this tower
+	SourceTech                      // tech tree unlocks
+	SourceSkill                     // skill tree unlocks
+	SourceClass                     // character class passives
+	SourceSynergy                   // banner auras and row combos
+	SourceTyping                    // typing performance and challenge words
+)
+
+var statSourceNames = [...]string{"base", "type", "level", "shop", "tech", "skill", "class", "synergy", "typing"}
+
+// String returns the short name shown in stat breakdowns.
+func (s StatSource) String() string {
+	if s < 0 || int(s) >= len(statSourceNames) {
+		return "?"
+	}
+	return statSourceNames[s]
+}
+
+// TowerStats holds the numbers that define how a tower fights. Damage is kept
+// fractional through the pipeline and only rounded once at the end.
+type TowerStats struct {
+	Damage       float64
+	Range        float64
+	Rate         float64 // seconds between shots
+	AmmoCapacity int
+	Projectiles  int
+	Bounce       int
+	Foresight    int
+}
+
+// StatModifier adjusts tower stats. Flat bonuses are added before multipliers
+// are applied and a zero multiplier leaves its stat unchanged.
+type StatModifier struct {
+	DamageAdd    float64
+	DamageMult   float64
+	RangeAdd     float64
+	RangeMult    float64
+	RateMult     float64
+	AmmoAdd      int
+	ForesightAdd int
+}
+
+// apply returns s adjusted by the modifier.
+func (m StatModifier) apply(s TowerStats) TowerStats {
+	s.Damage += m.DamageAdd
+	if m.DamageMult != 0 {
+		s.Damage *= m.DamageMult
+	}
+	s.Range += m.RangeAdd
+	if m.RangeMult != 0 {
+		s.Range *= m.RangeMult
+	}
+	if m.RateMult != 0 {
+		s.Rate *= m.RateMult
+	}
+	s.AmmoCapacity += m.AmmoAdd
+	s.Foresight += m.ForesightAdd
+	return s
+}
+
+// statModifierFrom converts global tower modifiers into a pipeline modifier.
+func statModifierFrom(m TowerModifiers) StatModifier {
+	return StatModifier{
+		DamageMult: m.DamageMult,
+		RangeMult:  m.RangeMult,
+		RateMult:   m.FireRateMult,
+		AmmoAdd:    m.AmmoAdd,
+	}
+}
+
+// StatLayer records one source's contribution to a tower's derived stats.
+type StatLayer struct {
+	Source StatSource
+	Mod    StatModifier
+	Stats  TowerStats // stats after this layer was applied
+}
+
+// deriveStats runs base through the modifiers in order and returns the final
+// stats along with the stats after every layer.
+func deriveStats(base TowerStats, mods []StatLayer) (TowerStats, []StatLayer) {
+	s := base
+	layers := make([]StatLayer, 0, len(mods)+1)
+	layers = append(layers, StatLayer{Source: SourceBase, Stats: s})
+	for _, l := range mods {
+		s = l.Mod.apply(s)
+		l.Stats = s
+		layers = append(layers, l)
+	}
+	return s, layers
+}
+
+// baseTowerStats returns the configured tower stats, falling back to the
+// defaults for unset values.
+func baseTowerStats(cfg Config) TowerStats {
+	pick := func(v, def float64) float64 {
+		if v > 0 {
+			return v
+		}
+		return def
+	}
+	s := TowerStats{
+		Damage:       pick(float64(cfg.TowerDamage), float64(DefaultConfig.TowerDamage)),
+		Range:        pick(cfg.TowerRange, DefaultConfig.TowerRange),
+		Rate:         pick(cfg.TowerFireRate, DefaultConfig.TowerFireRate) * 3.0, // towers fire much slower than the configured cadence
+		AmmoCapacity: int(pick(float64(cfg.TowerAmmoCapacity), float64(DefaultConfig.TowerAmmoCapacity))),
+		Projectiles:  int(pick(float64(cfg.TowerProjectiles), float64(DefaultConfig.TowerProjectiles))),
+		Bounce:       cfg.TowerBounce,
+		Foresight:    baseForesight,
+	}
+	if cfg.F > 0 {
+		s.Rate /= cfg.F
+	}
+	if s.Bounce < 0 {
+		s.Bounce = 0
+	}
+	return s
+}
+
+// towerTypeModifier returns the stat adjustments of a tower type.
+func towerTypeModifier(tt TowerType) StatModifier {
+	switch tt {
+	case TowerSniper:
+		return StatModifier{DamageMult: 3, RangeMult: 2, RateMult: 2.5, AmmoAdd: -2}
+	case TowerRapid:
+		return StatModifier{DamageMult: 0.5, RangeMult: 0.7, RateMult: 0.4, AmmoAdd: 1}
+	case TowerBallista:
+		return StatModifier{DamageMult: 2, RangeMult: 1.5, RateMult: 1.5, AmmoAdd: -1}
+	}
+	return StatModifier{}
+}
+
+// towerLevelModifier returns the stat adjustments granted by a tower level.
+func towerLevelModifier(level int) StatModifier {
+	if level <= 1 {
+		return StatModifier{}
+	}
+	n := level - 1
+	return StatModifier{
+		DamageAdd: float64(n),
+		RangeAdd:  float64(upgradeRangeStep * n),
+		RateMult:  1 - 0.1*float64(n),
+		AmmoAdd:   n,
+	}
+}
+
+// statLayers lists the modifier sources that apply to the tower in order.
+func (t *Tower) statLayers() []StatLayer {
+	layers := []StatLayer{
+		{Source: SourceType, Mod: towerTypeModifier(t.towerType)},
+		{Source: SourceLevel, Mod: towerLevelModifier(t.level)},
+		{Source: SourceShop, Mod: t.upgrades},
+	}
+	if t.game != nil {
+		layers = append(layers,
+			StatLayer{Source: SourceTech, Mod: statModifierFrom(t.game.techMods)},
+			StatLayer{Source: SourceSkill, Mod: statModifierFrom(t.game.skillMods)},
+		)
+		if t.game.class != nil {
+			layers = append(layers, StatLayer{Source: SourceClass, Mod: t.game.classStatModifier()})
+		}
+	}
+	layers = append(layers, StatLayer{Source: SourceSynergy, Mod: StatModifier{DamageAdd: float64(t.synergy.Damage)}})
+	typing := StatModifier{}
+	if !t.bonusTimer.Ready() {
+		typing.DamageAdd = float64(t.damageBonus)
+	}
+	typing.RateMult = t.currentTypingRate()
+	return append(layers, StatLayer{Source: SourceTyping, Mod: typing})
+}
+
+// currentTypingRate returns the fire rate multiplier earned by the player's
+// typing, or 0 for no change when the tower has no game.
+func (t *Tower) currentTypingRate() float64 {
+	if t.game == nil {
+		return 0
+	}
+	return t.game.typing.RateMultiplier()
+}
+
+// recomputeStats derives the tower's stats from its base stats and modifier
+// sources. Derived values are never fed back into the pipeline, so calling it
+// repeatedly does not compound any modifier.
+func (t *Tower) recomputeStats() {
+	t.typingRate = t.currentTypingRate()
+	var s TowerStats
+	if t.towerType == TowerBanner {
+		// Banners only project an aura and ignore every modifier
+		s, t.breakdown = deriveStats(TowerStats{Range: float64(bannerRadius*TileSize + TileSize/2)}, nil)
+	} else {
+		s, t.breakdown = deriveStats(t.base, t.statLayers())
+	}
+
+	t.damage = int(math.Round(s.Damage))
+	if t.damage < 1 && t.towerType != TowerBanner {
+		t.damage = 1
+	}
+	t.rate = math.Max(s.Rate, minTowerRate)
+	if t.towerType == TowerBanner {
+		t.rate = 0
+	}
+	if s.AmmoCapacity < 1 && t.towerType != TowerBanner {
+		s.AmmoCapacity = 1
+	}
+	t.projectiles = s.Projectiles
+	t.bounce = s.Bounce
+	t.foresight = s.Foresight
+	if t.foresight > maxForesight {
+		t.foresight = maxForesight
+	}
+	if s.Range != t.rangeDst || t.rangeImg == nil {
+		t.rangeDst = s.Range
+		t.rangeImg = generateRangeImage(t.rangeDst)
+	}
+	t.cooldownTimer.SetInterval(t.rate)
+	t.resizeAmmo(s.AmmoCapacity)
+}
+
+// resizeAmmo changes the ammo capacity, keeping loaded rounds and filling any
+// new slots.
+func (t *Tower) resizeAmmo(capacity int) {
+	if capacity == t.ammoCapacity && len(t.ammoQueue) == capacity {
+		return
+	}
+	t.ammoCapacity = capacity
+	q := make([]bool, capacity)
+	copy(q, t.ammoQueue)
+	for i := len(t.ammoQueue); i < capacity; i++ {
+		q[i] = true
+	}
+	t.ammoQueue = q
+}
+
+// StatBreakdown returns the layers that produced the tower's current stats,
+// starting with the base layer.
+func (t *Tower) StatBreakdown() []StatLayer { return t.breakdown }
@@ -0,0 +1,74 @@
+package game
+
+import "testing"
+
+func TestRecomputeStatsDoesNotCompound(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: NewInput(), typing: NewTypingStats()}
+	g.techMods = TowerModifiers{DamageMult: 1.5, FireRateMult: 0.5}
+	tower := NewTower(g, 0, 0)
+	dmg, rate := tower.damage, tower.rate
+	for i := 0; i < 5; i++ {
+		tower.recomputeStats()
+		tower.Update(0.016)
+	}
+	if tower.damage != dmg || tower.rate != rate {
+		t.Fatalf("stats drifted: damage %d->%d rate %f->%f", dmg, tower.damage, rate, tower.rate)
+	}
+	if tower.cooldownTimer.interval != tower.rate {
+		t.Errorf("cooldown interval %f should match derived rate %f", tower.cooldownTimer.interval, tower.rate)
+	}
+}
+
+func TestApplyConfigKeepsUpgrades(t *testing.T) {
+	cfg := DefaultConfig
+	g := &Game{cfg: &cfg}
+	tower := NewTower(g, 0, 0)
+	tower.UpgradeDamage()
+	tower.UpgradeRange()
+	cfg.TowerDamage = 4
+	tower.ApplyConfig(cfg)
+	if tower.damage != 5 {
+		t.Errorf("expected reloaded base 4 plus upgrade, got %d", tower.damage)
+	}
+	if tower.rangeDst != cfg.TowerRange+upgradeRangeStep {
+		t.Errorf("range upgrade lost on config reload, got %f", tower.rangeDst)
+	}
+}
+
+func TestDamageRoundsOnceAtEnd(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.TowerDamage = 3
+	g := &Game{cfg: &cfg}
+	g.techMods = TowerModifiers{DamageMult: 1.1 * 1.1}
+	g.skillMods = TowerModifiers{DamageMult: 1.1 * 1.1}
+	tower := NewTower(g, 0, 0)
+	// 3 * 1.4641 = 4.39; truncating after each step would give 3
+	if tower.damage != 4 {
+		t.Fatalf("expected damage 4 got %d", tower.damage)
+	}
+}
+
+func TestStatBreakdownOrder(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig}
+	g.skillMods = TowerModifiers{RangeMult: 2}
+	tower := NewTowerWithTypeAndLevel(g, 0, 0, TowerSniper, 2)
+	tower.UpgradeAmmoCapacity(2)
+	layers := tower.StatBreakdown()
+	want := []StatSource{SourceBase, SourceType, SourceLevel, SourceShop, SourceTech, SourceSkill, SourceSynergy, SourceTyping}
+	if len(layers) != len(want) {
+		t.Fatalf("expected %d layers got %d", len(want), len(layers))
+	}
+	for i, l := range layers {
+		if l.Source != want[i] {
+			t.Errorf("layer %d: expected %s got %s", i, want[i], l.Source)
+		}
+	}
+	last := layers[len(layers)-1].Stats
+	if last.Range != tower.rangeDst || last.AmmoCapacity != tower.ammoCapacity {
+		t.Errorf("breakdown does not end at derived stats")
+	}
+	lines := towerStatLines(tower)
+	if len(lines) != 5 || lines[0] != "Damage 4: base 1, type +2, level +1" {
+		t.Errorf("unexpected breakdown lines %q", lines)
+	}
+}
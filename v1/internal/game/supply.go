@@ -0,0 +1,105 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	baseSupply  = 4    // supply available without any houses
+	houseSupply = 4    // supply added by every house
+	moraleStep  = 0.25 // morale lost per unfed wave and regained per fed wave
+	minMorale   = 0.25
+	fullMorale  = 1.0
+)
+
+// SupplyCap returns the supply the army may use: the base supply plus the
+// supply of every house.
+func (g *Game) SupplyCap() int { return baseSupply + houseSupply*len(g.houseTiles) }
+
+// SupplyUsed returns the supply taken by living units.
+func (g *Game) SupplyUsed() int {
+	if g.military == nil {
+		return 0
+	}
+	return g.military.Supply()
+}
+
+// Morale returns the army's morale, 1 when fully fed.
+func (g *Game) Morale() float64 { return g.morale }
+
+// setMorale changes the army's morale within its bounds.
+func (g *Game) setMorale(m float64) {
+	g.morale = math.Max(minMorale, math.Min(fullMorale, m))
+	if g.military != nil {
+		g.military.SetMorale(g.morale)
+	}
+}
+
+// payUpkeep feeds the army at the start of a wave. Each unit eats its supply
+// in Food. A fed army regains morale; when the food runs out the army eats
+// what is left and morale falls.
+func (g *Game) payUpkeep() {
+	need := g.SupplyUsed()
+	if need == 0 {
+		g.setMorale(g.morale + moraleStep)
+		return
+	}
+	if g.resources.Food.Spend(need) {
+		g.setMorale(g.morale + moraleStep)
+		return
+	}
+	g.resources.Food.Set(0)
+	g.setMorale(g.morale - moraleStep)
+}
+
+// refreshSupplyCap tells the Barracks how much supply the houses provide.
+func (g *Game) refreshSupplyCap() {
+	if g.barracks != nil {
+		g.barracks.SetSupplyCap(g.SupplyCap())
+	}
+}
+
+// isHouse reports whether a house stands on the tile.
+func (g *Game) isHouse(tileX, tileY int) bool {
+	for _, h := range g.houseTiles {
+		if h == [2]int{tileX, tileY} {
+			return true
+		}
+	}
+	return false
+}
+
+// buildHouseAtCursor builds a house on the cursor tile, raising the supply
+// cap. Houses block paths like towers do.
+func (g *Game) buildHouseAtCursor() bool {
+	if !g.resources.CanAfford(g.costs().House) || !g.validTowerPosition(g.cursorX, g.cursorY) {
+		return false
+	}
+	g.resources.Spend(g.costs().House)
+	g.houseTiles = append(g.houseTiles, [2]int{g.cursorX, g.cursorY})
+	g.refreshSupplyCap()
+	g.invalidatePaths()
+	return true
+}
+
+// drawHouses draws every house tile.
+func (g *Game) drawHouses(screen *ebiten.Image) {
+	for _, h := range g.houseTiles {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(h[0]*TileSize), float64(TopMargin+h[1]*TileSize))
+		screen.DrawImage(ImgHouseTile, op)
+	}
+}
+
+// supplyLine is the HUD text for supply, e.g. "Supply 3/8" or "Supply 9/8
+// Morale 50%" once the army goes hungry.
+func (g *Game) supplyLine() string {
+	line := fmt.Sprintf("Supply %d/%d", g.SupplyUsed(), g.SupplyCap())
+	if g.morale < fullMorale {
+		line += fmt.Sprintf(" Morale %.0f%%", g.morale*100)
+	}
+	return line
+}
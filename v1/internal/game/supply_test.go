@@ -0,0 +1,79 @@
+//go:build test
+
+package game
+
+import "testing"
+
+func TestUpkeepFeedsArmy(t *testing.T) {
+	g := NewGame()
+	g.military.AddUnit(NewFootman(0, 0))
+	g.military.AddUnit(NewFootman(0, 0))
+	g.resources.Food.Set(5)
+	g.payUpkeep()
+	if g.resources.FoodAmount() != 3 || g.Morale() != fullMorale {
+		t.Errorf("two footmen should eat 2 food, food %d morale %v", g.resources.FoodAmount(), g.Morale())
+	}
+}
+
+func TestHungryArmyLosesMorale(t *testing.T) {
+	g := NewGame()
+	k := NewUnit(*DefaultRoster().Stats(UnitKnight), 0, 0)
+	g.military.AddUnit(k)
+	g.resources.Food.Set(1)
+	g.payUpkeep()
+	g.payUpkeep()
+	if g.resources.FoodAmount() != 0 || g.Morale() != 0.5 {
+		t.Fatalf("unfed waves should drain food and morale, food %d morale %v", g.resources.FoodAmount(), g.Morale())
+	}
+	if hit := k.body().hit(); hit != 1 {
+		t.Errorf("a knight at half morale should hit for 1, got %d", hit)
+	}
+	if line := g.supplyLine(); line != "Supply 2/4 Morale 50%" {
+		t.Errorf("unexpected supply line %q", line)
+	}
+	g.resources.Food.Set(10)
+	g.payUpkeep()
+	if g.Morale() != 0.75 {
+		t.Errorf("a fed wave should restore some morale, got %v", g.Morale())
+	}
+}
+
+func TestHousesRaiseSupplyCap(t *testing.T) {
+	g := NewGame()
+	g.resources.Refund(g.costs().House)
+	g.cursorX, g.cursorY = -1, -1
+	for x := 0; x < gridCols && g.cursorX < 0; x++ {
+		for y := 0; y < gridRows; y++ {
+			if g.validTowerPosition(x, y) {
+				g.cursorX, g.cursorY = x, y
+				break
+			}
+		}
+	}
+	if !g.buildHouseAtCursor() {
+		t.Fatalf("house should be built")
+	}
+	if g.SupplyCap() != baseSupply+houseSupply || g.barracks.supplyCap != g.SupplyCap() {
+		t.Errorf("a house should raise the cap to %d, got %d", baseSupply+houseSupply, g.SupplyCap())
+	}
+	if g.validTowerPosition(g.cursorX, g.cursorY) {
+		t.Errorf("towers should not be built on houses")
+	}
+	if g.buildHouseAtCursor() {
+		t.Errorf("houses should cost resources")
+	}
+}
+
+func TestBarracksStopsAtSupplyCap(t *testing.T) {
+	g := NewGame()
+	for i := 0; i < baseSupply; i++ {
+		g.military.AddUnit(NewFootman(0, 0))
+	}
+	if !g.barracks.SupplyFull() {
+		t.Fatalf("barracks should be full at %d/%d", g.SupplyUsed(), g.SupplyCap())
+	}
+	g.barracks.SetCooldown(0)
+	if w := g.barracks.Update(1); w != "" {
+		t.Errorf("a full barracks should not offer words, got %q", w)
+	}
+}
@@ -0,0 +1,153 @@
+package game
+
+const (
+	bannerRadius      = 2 // aura radius of a Banner tower in tiles
+	bannerDamageBonus = 1 // damage granted to each tower inside a Banner aura
+	comboLength       = 3 // same-type towers in a row needed for a combo
+	comboDamageBonus  = 1 // damage granted to every tower in a combo row
+)
+
+// towerSynergy holds the bonuses a tower receives from its neighbours.
+type towerSynergy struct {
+	Damage    int // flat damage from banners and combos
+	Foresight int // foresight shared by an adjacent sniper, 0 if none
+	Combo     bool
+}
+
+// synergySlot describes a tower's placement on the tile grid for synergy
+// evaluation. It lets placements be previewed before a tower exists.
+type synergySlot struct {
+	x, y      int
+	tt        TowerType
+	foresight int
+}
+
+// towerTile returns the grid tile a tower occupies.
+func towerTile(t *Tower) (int, int) {
+	return tileAtPosition(int(t.pos.X), int(t.pos.Y))
+}
+
+// chebyshev returns the tile distance allowing diagonal steps.
+func chebyshev(ax, ay, bx, by int) int {
+	dx, dy := ax-bx, ay-by
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// computeSynergies evaluates banner auras, sniper foresight sharing and
+// row combos for the given slots. The result is aligned with slots.
+func computeSynergies(slots []synergySlot) []towerSynergy {
+	out := make([]towerSynergy, len(slots))
+	occupied := make(map[[2]int]TowerType, len(slots))
+	for _, s := range slots {
+		occupied[[2]int{s.x, s.y}] = s.tt
+	}
+	for i, s := range slots {
+		for j, o := range slots {
+			if i == j {
+				continue
+			}
+			d := chebyshev(s.x, s.y, o.x, o.y)
+			if o.tt == TowerBanner && s.tt != TowerBanner && d <= bannerRadius {
+				out[i].Damage += bannerDamageBonus
+			}
+			if s.tt == TowerRapid && o.tt == TowerSniper && d == 1 && o.foresight > out[i].Foresight {
+				out[i].Foresight = o.foresight
+			}
+		}
+		if s.tt != TowerBanner && inCombo(occupied, s) {
+			out[i].Combo = true
+			out[i].Damage += comboDamageBonus
+		}
+	}
+	return out
+}
+
+// inCombo reports whether s is part of a horizontal or vertical run of at
+// least comboLength towers of its type.
+func inCombo(occupied map[[2]int]TowerType, s synergySlot) bool {
+	run := func(dx, dy int) int {
+		n := 1
+		for k := 1; ; k++ {
+			tt, ok := occupied[[2]int{s.x + dx*k, s.y + dy*k}]
+			if !ok || tt != s.tt {
+				break
+			}
+			n++
+		}
+		for k := 1; ; k++ {
+			tt, ok := occupied[[2]int{s.x - dx*k, s.y - dy*k}]
+			if !ok || tt != s.tt {
+				break
+			}
+			n++
+		}
+		return n
+	}
+	return run(1, 0) >= comboLength || run(0, 1) >= comboLength
+}
+
+// synergySlots converts the game's towers into synergy slots.
+func (g *Game) synergySlots() []synergySlot {
+	slots := make([]synergySlot, len(g.towers))
+	for i, t := range g.towers {
+		x, y := towerTile(t)
+		slots[i] = synergySlot{x: x, y: y, tt: t.towerType, foresight: t.foresight}
+	}
+	return slots
+}
+
+// applySynergies recomputes neighbour bonuses for every tower.
+func (g *Game) applySynergies() {
+	syn := computeSynergies(g.synergySlots())
+	for i, t := range g.towers {
+		if t.synergy != syn[i] {
+			t.synergy = syn[i]
+			t.recomputeStats()
+		}
+	}
+}
+
+// synergyPreview returns the tiles that would gain or lose a bonus if a tower
+// of type tt were placed at (tileX, tileY), including the placed tile itself
+// when it would receive a bonus. Banner placements also return their aura.
+func (g *Game) synergyPreview(tileX, tileY int, tt TowerType) [][2]int {
+	slots := g.synergySlots()
+	before := computeSynergies(slots)
+	after := computeSynergies(append(slots, synergySlot{x: tileX, y: tileY, tt: tt, foresight: 5}))
+	seen := make(map[[2]int]bool)
+	var tiles [][2]int
+	add := func(x, y int) {
+		k := [2]int{x, y}
+		if !seen[k] && x >= 0 && x <= 59 && y >= 0 && y <= 33 {
+			seen[k] = true
+			tiles = append(tiles, k)
+		}
+	}
+	for i := range before {
+		if before[i] != after[i] {
+			add(slots[i].x, slots[i].y)
+		}
+	}
+	if after[len(after)-1] != (towerSynergy{}) {
+		add(tileX, tileY)
+	}
+	if tt == TowerBanner {
+		for dx := -bannerRadius; dx <= bannerRadius; dx++ {
+			for dy := -bannerRadius; dy <= bannerRadius; dy++ {
+				if dx != 0 || dy != 0 {
+					add(tileX+dx, tileY+dy)
+				}
+			}
+		}
+	}
+	return tiles
+}
@@ -0,0 +1,97 @@
+package game
+
+import "testing"
+
+func TestBannerAuraBoostsNeighbours(t *testing.T) {
+	syn := computeSynergies([]synergySlot{
+		{x: 5, y: 5, tt: TowerBanner},
+		{x: 6, y: 7, tt: TowerBasic},
+		{x: 9, y: 5, tt: TowerBasic},
+	})
+	if syn[1].Damage != bannerDamageBonus {
+		t.Errorf("tower inside aura should gain %d damage got %d", bannerDamageBonus, syn[1].Damage)
+	}
+	if syn[2].Damage != 0 {
+		t.Errorf("tower outside aura should gain no damage got %d", syn[2].Damage)
+	}
+	if syn[0].Damage != 0 {
+		t.Errorf("banner should not boost itself")
+	}
+}
+
+func TestRapidSharesSniperForesight(t *testing.T) {
+	syn := computeSynergies([]synergySlot{
+		{x: 5, y: 5, tt: TowerSniper, foresight: 8},
+		{x: 6, y: 5, tt: TowerRapid, foresight: 5},
+		{x: 8, y: 5, tt: TowerRapid, foresight: 5},
+	})
+	if syn[1].Foresight != 8 {
+		t.Errorf("adjacent rapid should share sniper foresight, got %d", syn[1].Foresight)
+	}
+	if syn[2].Foresight != 0 {
+		t.Errorf("distant rapid should not share foresight, got %d", syn[2].Foresight)
+	}
+}
+
+func TestThreeInARowCombo(t *testing.T) {
+	row := []synergySlot{
+		{x: 1, y: 1, tt: TowerBasic},
+		{x: 2, y: 1, tt: TowerBasic},
+		{x: 3, y: 1, tt: TowerBasic},
+		{x: 4, y: 1, tt: TowerSniper},
+	}
+	syn := computeSynergies(row)
+	for i := 0; i < 3; i++ {
+		if !syn[i].Combo || syn[i].Damage != comboDamageBonus {
+			t.Errorf("tower %d should be in a combo: %+v", i, syn[i])
+		}
+	}
+	if syn[3].Combo {
+		t.Errorf("different tower type should not join the combo")
+	}
+	syn = computeSynergies(row[:2])
+	if syn[0].Combo {
+		t.Errorf("two towers should not form a combo")
+	}
+}
+
+func TestSynergyPreviewBanner(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: NewInput(), typing: NewTypingStats()}
+	tx, ty := tilePosition(5, 5)
+	g.towers = []*Tower{NewTower(g, float64(tx+TileSize/2), float64(ty+TileSize/2))}
+	tiles := g.synergyPreview(6, 5, TowerBanner)
+	found := false
+	for _, tile := range tiles {
+		if tile == [2]int{5, 5} {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("preview should include boosted tower tile, got %v", tiles)
+	}
+	if len(g.synergyPreview(20, 20, TowerBasic)) != 0 {
+		t.Errorf("isolated basic tower should not preview any synergy")
+	}
+}
+
+func TestSynergyDamageCountedOnce(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: NewInput(), typing: NewTypingStats()}
+	tower := NewTower(g, 0, 0)
+	base := tower.damage
+	tower.synergy.Damage = comboDamageBonus
+	tower.damageBonus = 3
+	tower.bonusTimer.Reset()
+	tower.recomputeStats()
+	tower.cooldownTimer.remaining = 0
+	for i := range tower.ammoQueue {
+		tower.ammoQueue[i] = true
+	}
+	g.mobs = []Enemy{NewMob(10, 0, NewBase(500, 0, 10), 50, 0)}
+	tower.Update(0)
+	if len(g.projectiles) != 1 {
+		t.Fatalf("tower should fire once, got %d projectiles", len(g.projectiles))
+	}
+	if want := base + comboDamageBonus + 3; tower.damage != want || g.projectiles[0].damage != want {
+		t.Errorf("projectile damage %d, stat %d, want %d", g.projectiles[0].damage, tower.damage, want)
+	}
+}
@@ -6,6 +6,7 @@ type TechNode struct {
 	Letters     []rune
 	Modifiers   TowerModifiers
 	Achievement string
+	Drills      []ReloadDrill // reload drills granted to every tower
 }
 
 // TechTree manages sequential technology unlocks.
@@ -18,12 +19,12 @@ type TechTree struct {
 func DefaultTechTree() *TechTree {
 	nodes := []TechNode{
 		{Name: "Home Row", Letters: []rune{'f', 'j'}, Achievement: "Unlock F & J"},
-		{Name: "Index Extensions", Letters: []rune{'d', 'k'}, Achievement: "Unlock D & K", Modifiers: TowerModifiers{RangeMult: 1.05}},
+		{Name: "Index Extensions", Letters: []rune{'d', 'k'}, Achievement: "Unlock D & K", Modifiers: TowerModifiers{RangeMult: 1.05}, Drills: []ReloadDrill{{Text: "fdjk", Ammo: 5}}},
 		{Name: "Middle Fingers", Letters: []rune{'s', 'l'}, Achievement: "Unlock S & L", Modifiers: TowerModifiers{DamageMult: 1.1}},
-		{Name: "Ring Finger", Letters: []rune{'a'}, Achievement: "Unlock A", Modifiers: TowerModifiers{AmmoAdd: 1}},
-		{Name: "Inner Index", Letters: []rune{'g', 'h'}, Achievement: "Unlock G & H", Modifiers: TowerModifiers{FireRateMult: 0.95}},
+		{Name: "Ring Finger", Letters: []rune{'a'}, Achievement: "Unlock A", Modifiers: TowerModifiers{AmmoAdd: 1}, Drills: []ReloadDrill{{Text: "flask", Ammo: 7}, {Text: "salad", Ammo: 7}}},
+		{Name: "Inner Index", Letters: []rune{'g', 'h'}, Achievement: "Unlock G & H", Modifiers: TowerModifiers{FireRateMult: 0.95}, Drills: []ReloadDrill{{Text: "glass", Ammo: 7}}},
 		{Name: "Top Row Pinky", Letters: []rune{'q', 'p'}, Achievement: "Unlock Q & P", Modifiers: TowerModifiers{DamageMult: 1.1}},
-		{Name: "Top Row Middle", Letters: []rune{'e', 'i'}, Achievement: "Unlock E & I", Modifiers: TowerModifiers{RangeMult: 1.05}},
+		{Name: "Top Row Middle", Letters: []rune{'e', 'i'}, Achievement: "Unlock E & I", Modifiers: TowerModifiers{RangeMult: 1.05}, Drills: []ReloadDrill{{Text: "shield", Ammo: 9}}},
 		{Name: "Top Row Index", Letters: []rune{'r', 'u'}, Achievement: "Unlock R & U", Modifiers: TowerModifiers{AmmoAdd: 1}},
 		{Name: "Top Row Outer", Letters: []rune{'t', 'y'}, Achievement: "Unlock T & Y", Modifiers: TowerModifiers{FireRateMult: 0.95}},
 		{Name: "Top Row Ring", Letters: []rune{'w', 'o'}, Achievement: "Unlock W & O", Modifiers: TowerModifiers{DamageMult: 1.1}},
@@ -44,6 +45,17 @@ func (t *TechTree) UnlockNext() (letters []rune, achievement string, mods TowerM
 	return node.Letters, node.Achievement, node.Modifiers
 }
 
+// stageOf returns the stage of the named node, or -1 when it is not in the
+// tree.
+func (t *TechTree) stageOf(name string) int {
+	for i, n := range t.nodes {
+		if n.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
 // Completed returns true if all tech nodes have been unlocked.
 func (t *TechTree) Completed() bool {
 	return t.stage >= len(t.nodes)
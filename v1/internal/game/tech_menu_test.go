@@ -37,6 +37,7 @@ func (t *techInput) SelectTower() bool { return false }
 func (t *techInput) Command() bool     { return false }
 func (t *techInput) TechMenu() bool    { v := t.toggle; t.toggle = false; return v }
 func (t *techInput) SkillMenu() bool   { return false }
+func (t *techInput) StatsPanel() bool  { return false }
 
 func TestTechMenuToggle(t *testing.T) {
 	g := NewGame()
@@ -72,3 +73,21 @@ func TestTechMenuPurchase(t *testing.T) {
 		t.Fatalf("expected tech stage 1 got %d", g.techTree.stage)
 	}
 }
+
+func TestWavesOnlyGrantFirstTech(t *testing.T) {
+	g := NewGame()
+	g.startWave()
+	g.currentWave = 2
+	g.startWave()
+	if g.techTree.stage != 1 {
+		t.Errorf("later tech nodes should be bought, stage %d", g.techTree.stage)
+	}
+}
+
+func TestTechStageOfFilteredNode(t *testing.T) {
+	tree := DefaultTechTree()
+	tree.stage = 2
+	if got := tree.stageOf("Inner Index"); got != 4 {
+		t.Errorf("Inner Index should be priced as stage 4, got %d", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"unicode"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // TowerType represents the variety of tower with unique stats.
@@ -16,14 +17,20 @@ const (
 	TowerBasic TowerType = iota
 	TowerSniper
 	TowerRapid
+	TowerBanner   // support tower that boosts neighbours instead of firing
+	TowerBallista // heavy bolt thrower that can hit flying enemies
 )
 
 // Tower represents a stationary auto-firing tower.
 type Tower struct {
 	BaseEntity
 	cooldownTimer CooldownTimer // Use proper timer instead of float64
-	rate          float64       // seconds between shots
-	rangeDst      float64
+	base          TowerStats    // stats before any modifier source
+	upgrades      StatModifier  // shop upgrades bought for this tower
+	breakdown     []StatLayer   // layers behind the derived stats
+	rate          float64       // derived seconds between shots
+	typingRate    float64       // typing fire rate multiplier the stats were derived with
+	rangeDst      float64       // derived range
 	game          *Game
 	rangeImg      *ebiten.Image
 
@@ -34,18 +41,27 @@ type Tower struct {
 	ammoQueue    []bool // ready-to-fire ammunition (true = loaded, false = empty)
 	reloadQueue  []rune // letters that need to be typed to reload
 	ammoCapacity int    // maximum size of ammoQueue
-	damage       int
+	damage       int    // derived damage per shot, including bonuses
 	projectiles  int
 	bounce       int
 	level        int
 	jammed       bool
 	jammedLetter rune // preserve letter when jammed
 	foresight    int  // number of reload letters to preview
+	shot         ProjectileProfile
+	synergy      towerSynergy // bonuses from neighbouring towers
+	invested     Cost         // resources spent building and upgrading
+	hp           int          // remaining hit points against ranged enemies
+	maxHP        int
+	repairWord   string // queued repair word while damaged
 
 	// Advanced reload mechanics
-	reloadSeq       []rune // optional fixed reload sequence
-	reloadIdx       int    // index into reloadSeq
-	challengeWord   []rune // special challenge sequence
+	reloadSeq       []rune        // optional fixed reload sequence
+	reloadIdx       int           // index into reloadSeq
+	drills          []ReloadDrill // words or bigrams that reload several slots
+	drillLeft       int           // letters of the active drill still queued
+	drillAmmo       int           // ammo loaded when the active drill completes
+	challengeWord   []rune        // special challenge sequence
 	challengeIdx    int
 	challengeActive bool
 	bonusTimer      CooldownTimer // Use timer for bonus duration
@@ -93,103 +109,33 @@ func NewTowerWithTypeAndLevel(g *Game, x, y float64, tt TowerType, level int) *T
 			frameAnchorY: float64(h) / 2,
 			static:       true,
 		},
-		cooldownTimer: NewCooldownTimer(cfg.TowerFireRate * 3.0), // Much slower firing
-		rangeDst:      cfg.TowerRange,
-		game:          g,
-		ammoCapacity:  cfg.TowerAmmoCapacity,
-		damage:        cfg.TowerDamage,
-		projectiles:   cfg.TowerProjectiles,
-		bounce:        cfg.TowerBounce,
-		level:         level,
-		jammed:        false,
-		foresight:     5,
-		damageBonus:   0,
-		towerType:     tt,
-		bonusTimer:    NewCooldownTimer(5.0),
-	}
-
-	// Initialize ammo queue with full capacity
-	t.ammoQueue = make([]bool, t.ammoCapacity)
-	for i := range t.ammoQueue {
-		t.ammoQueue[i] = true
-	}
-	t.reloadQueue = make([]rune, 0)
-
-	// Generate range image
-	t.rangeImg = generateRangeImage(t.rangeDst)
-
-	// Apply game config if available
-	if g.cfg != nil {
-		t.ApplyConfig(*g.cfg)
-	}
-
-	// Apply tower type-specific stats AFTER config application
-	// to ensure tower types maintain their unique characteristics
-	switch tt {
-	case TowerSniper:
-		t.damage *= 3
-		t.rangeDst *= 2.0                                           // Ensure sniper has significantly longer range
-		t.cooldownTimer.SetInterval(t.cooldownTimer.interval * 2.5) // slower fire rate
-		t.rate *= 2.5                                               // update rate for display/upgrades
-		t.ammoCapacity = 3
-	case TowerRapid:
-		if t.damage > 1 {
-			t.damage /= 2
+		base:        baseTowerStats(cfg),
+		game:        g,
+		level:       level,
+		jammed:      false,
+		damageBonus: 0,
+		towerType:   tt,
+		bonusTimer:  NewCooldownTimer(5.0),
+		drills:      defaultDrills(tt),
+		shot:        defaultProjectileProfile(tt),
+		maxHP:       towerBaseHP + towerHPPerLevel*(level-1),
+	}
+	t.hp = t.maxHP
+	t.bonusTimer.remaining = 0 // Start without an active bonus
+	if g != nil {
+		for _, d := range g.drills {
+			t.AddDrill(d)
 		}
-		t.rangeDst *= 0.7
-		t.cooldownTimer.SetInterval(t.cooldownTimer.interval * 0.4) // faster fire rate
-		t.rate *= 0.4                                               // update rate for display/upgrades
-		t.ammoCapacity = 6
 	}
 
-	// Apply level upgrades after all type-specific modifications
-	t.applyLevel()
-
-	// Regenerate range image with the final range distance
-	t.rangeImg = generateRangeImage(t.rangeDst)
-
-	// Ensure ammo capacity is consistent with the queue size
-	if len(t.ammoQueue) != t.ammoCapacity {
-		newAmmoQueue := make([]bool, t.ammoCapacity)
-		for i := 0; i < t.ammoCapacity && i < len(t.ammoQueue); i++ {
-			newAmmoQueue[i] = t.ammoQueue[i]
-		}
-		for i := len(t.ammoQueue); i < t.ammoCapacity; i++ {
-			newAmmoQueue[i] = true
-		}
-		t.ammoQueue = newAmmoQueue
-	}
+	// Derive stats and start with a full ammo queue
+	t.recomputeStats()
+	t.cooldownTimer = NewCooldownTimer(t.rate)
+	t.reloadQueue = make([]rune, 0)
 
 	return t
 }
 
-// applyLevel adjusts base stats according to the tower's level.
-func (t *Tower) applyLevel() {
-	switch t.level {
-	case 2:
-		t.damage++
-		t.rangeDst += 50
-		t.rate *= 0.9
-		t.ammoCapacity++
-	case 3:
-		t.damage += 2
-		t.rangeDst += 100
-		t.rate *= 0.8
-		t.ammoCapacity += 2
-	case 4:
-		t.damage += 3
-		t.rangeDst += 150
-		t.rate *= 0.7
-		t.ammoCapacity += 3
-	case 5:
-		t.damage += 4
-		t.rangeDst += 200
-		t.rate *= 0.6
-		t.ammoCapacity += 4
-	}
-	// Note: range image will be regenerated in the main constructor
-}
-
 func (t *Tower) randomReloadLetter() rune {
 	if len(t.reloadSeq) > 0 {
 		r := t.reloadSeq[t.reloadIdx%len(t.reloadSeq)]
@@ -206,37 +152,6 @@ func (t *Tower) randomReloadLetter() rune {
 	return 'j'
 }
 
-// ApplyModifiers multiplies tower stats according to the provided modifiers.
-func (t *Tower) ApplyModifiers(mod TowerModifiers) {
-	if mod.DamageMult != 0 {
-		t.damage = int(float64(t.damage) * mod.DamageMult)
-	}
-	if mod.RangeMult != 0 {
-		t.rangeDst *= mod.RangeMult
-		t.rangeImg = generateRangeImage(t.rangeDst)
-	}
-	if mod.FireRateMult != 0 {
-		t.rate *= mod.FireRateMult
-		if t.rate < 0.01 {
-			t.rate = 0.01
-		}
-	}
-	if mod.AmmoAdd != 0 {
-		t.ammoCapacity += mod.AmmoAdd
-		if t.ammoCapacity < 1 {
-			t.ammoCapacity = 1
-		}
-		newAmmo := make([]bool, t.ammoCapacity)
-		for i := 0; i < t.ammoCapacity && i < len(t.ammoQueue); i++ {
-			newAmmo[i] = t.ammoQueue[i]
-		}
-		for i := len(t.ammoQueue); i < t.ammoCapacity; i++ {
-			newAmmo[i] = true
-		}
-		t.ammoQueue = newAmmo
-	}
-}
-
 // SetReloadSequence sets a fixed reload sequence for the tower.
 func (t *Tower) SetReloadSequence(seq []rune) {
 	t.reloadSeq = seq
@@ -253,42 +168,11 @@ func (t *Tower) StartReloadChallenge(word string) {
 	t.challengeActive = true
 }
 
-// ApplyConfig updates tower parameters based on the provided config.
+// ApplyConfig replaces the tower's base stats with the configured values and
+// derives its stats again.
 func (t *Tower) ApplyConfig(cfg Config) {
-	if cfg.TowerFireRate > 0 {
-		t.rate = cfg.TowerFireRate / 1000.0 // convert ms to seconds
-	}
-	if cfg.F > 0 {
-		r := t.rate / cfg.F
-		if r < 0.01 {
-			r = 0.01
-		}
-		t.rate = r
-	}
-	if cfg.TowerRange > 0 {
-		t.rangeDst = cfg.TowerRange
-		t.rangeImg = generateRangeImage(t.rangeDst)
-	}
-	if cfg.TowerAmmoCapacity > 0 {
-		t.ammoCapacity = cfg.TowerAmmoCapacity
-		newAmmoQueue := make([]bool, t.ammoCapacity)
-		for i := 0; i < t.ammoCapacity && i < len(t.ammoQueue); i++ {
-			newAmmoQueue[i] = t.ammoQueue[i]
-		}
-		for i := len(t.ammoQueue); i < t.ammoCapacity; i++ {
-			newAmmoQueue[i] = true
-		}
-		t.ammoQueue = newAmmoQueue
-	}
-	if cfg.TowerDamage > 0 {
-		t.damage = cfg.TowerDamage
-	}
-	if cfg.TowerProjectiles > 0 {
-		t.projectiles = cfg.TowerProjectiles
-	}
-	if cfg.TowerBounce >= 0 {
-		t.bounce = cfg.TowerBounce
-	}
+	t.base = baseTowerStats(cfg)
+	t.recomputeStats()
 }
 
 // getAvailableAmmo counts ready-to-fire ammunition
@@ -313,7 +197,7 @@ func (t *Tower) consumeAmmo() bool {
 	return false
 }
 
-// fillReloadQueue adds random letters to reload queue for empty ammo slots
+// fillReloadQueue queues a reload drill or random letters for empty ammo slots
 func (t *Tower) fillReloadQueue() {
 	emptySlots := 0
 	for _, loaded := range t.ammoQueue {
@@ -322,22 +206,38 @@ func (t *Tower) fillReloadQueue() {
 		}
 	}
 
+	// Prefer a drill when nothing is queued yet
+	if len(t.reloadQueue) == 0 && emptySlots > 0 {
+		if d, ok := t.pickDrill(emptySlots); ok {
+			t.startDrill(d, emptySlots)
+		}
+	}
+
 	// Add letters to reload queue to match empty slots
-	for len(t.reloadQueue) < emptySlots {
+	for t.pendingAmmo() < emptySlots {
 		t.reloadQueue = append(t.reloadQueue, t.randomReloadLetter())
 	}
 
-	if !t.challengeActive && len(t.reloadQueue) == 0 && rand.Float64() < 0.05 {
-		t.StartReloadChallenge("bonus")
+	if !t.challengeActive && len(t.reloadQueue) == 0 && t.game != nil && rand.Float64() < 0.05 {
+		t.StartReloadChallenge(t.game.randomChallengeWord())
 	}
 }
 
 // Update handles tower firing logic.
 func (t *Tower) Update(dt float64) {
-	typed := t.game.input.TypedChars()
+	if t.towerType == TowerBanner {
+		return // banners only project an aura
+	}
+	typed := t.game.letters()
 
-	if !t.bonusTimer.Ready() {
-		t.bonusTimer.Tick(dt)
+	// Stats only change here when the typing bonus runs out or typing
+	// performance moves the fire rate
+	expired := !t.bonusTimer.Ready() && t.bonusTimer.Tick(dt)
+	if expired {
+		t.damageBonus = 0
+	}
+	if expired || t.currentTypingRate() != t.typingRate {
+		t.recomputeStats()
 	}
 
 	// Handle jam clearing
@@ -356,7 +256,10 @@ func (t *Tower) Update(dt float64) {
 				if t.challengeIdx >= len(t.challengeWord) {
 					t.challengeActive = false
 					t.challengeIdx = 0
+					// Longer challenge words grant a bigger temporary bonus
+					t.damageBonus = len(t.challengeWord)
 					t.bonusTimer.Reset()
+					t.recomputeStats()
 					if t.game != nil {
 						t.game.typing.Record(true)
 					}
@@ -385,12 +288,15 @@ func (t *Tower) Update(dt float64) {
 				// Successfully typed the first letter in reload queue
 				t.reloadQueue = t.reloadQueue[1:]
 
-				// Add ammo to first empty slot
-				for i := range t.ammoQueue {
-					if !t.ammoQueue[i] {
-						t.ammoQueue[i] = true
-						break
+				// Drill letters only pay out once the whole drill is typed
+				if t.drillLeft > 0 {
+					t.drillLeft--
+					if t.drillLeft == 0 {
+						t.loadAmmo(t.drillAmmo)
+						t.drillAmmo = 0
 					}
+				} else {
+					t.loadAmmo(1)
 				}
 				if t.game != nil {
 					t.game.typing.Record(true)
@@ -426,7 +332,7 @@ func (t *Tower) Update(dt float64) {
 	}
 	var targets []mobDist
 	for _, m := range t.game.mobs {
-		if !m.Alive() {
+		if !m.Alive() || !canHit(m, t.AntiAir()) {
 			continue
 		}
 		mx, my := m.Position()
@@ -482,11 +388,8 @@ func (t *Tower) Update(dt float64) {
 		}
 
 		if t.consumeAmmo() {
-			dmg := t.damage
-			if t.bonusTimer.Ready() {
-				dmg += t.damageBonus
-			}
-			p := NewProjectile(t.game, t.pos.X, t.pos.Y, targetMob, dmg, speed, t.bounce)
+			p := NewProjectileWithProfile(t.game, t.pos.X, t.pos.Y, targetMob, t.damage, speed, t.bounce, t.shot)
+			p.antiAir = t.AntiAir()
 			t.game.projectiles = append(t.game.projectiles, p)
 			shotsFired++
 		}
@@ -494,14 +397,9 @@ func (t *Tower) Update(dt float64) {
 
 	// Set cooldown only if we actually fired
 	if shotsFired > 0 {
-		mult := 1.0
-		if t.game != nil {
-			mult = t.game.typing.RateMultiplier()
-			if t.game.sound != nil {
-				t.game.sound.PlayBeep()
-			}
+		if t.game != nil && t.game.sound != nil {
+			t.game.sound.PlayBeep()
 		}
-		t.cooldownTimer.SetInterval(t.cooldownTimer.interval * mult)
 		t.cooldownTimer.Reset()
 	}
 }
@@ -521,41 +419,105 @@ func (t *Tower) GetReloadStatus() (bool, rune, []rune, float64, bool) {
 		currentLetter = t.reloadQueue[0]
 	}
 
-	previewQueue := make([]rune, 0, t.foresight)
-	for i := 0; i < len(t.reloadQueue) && i < t.foresight; i++ {
+	foresight := t.foresight
+	if t.synergy.Foresight > foresight {
+		foresight = t.synergy.Foresight
+	}
+	previewQueue := make([]rune, 0, foresight)
+	for i := 0; i < len(t.reloadQueue) && i < foresight; i++ {
 		previewQueue = append(previewQueue, t.reloadQueue[i])
 	}
 
 	return reloading, currentLetter, previewQueue, 0, t.jammed // timer always 0 now
 }
 
-// UpgradeAmmoCapacity increases the tower's ammunition capacity
+// UpgradeDamage adds one point of damage to the tower's shop upgrades.
+func (t *Tower) UpgradeDamage() {
+	t.upgrades.DamageAdd++
+	t.recomputeStats()
+}
+
+// UpgradeRange extends the tower's range through its shop upgrades.
+func (t *Tower) UpgradeRange() {
+	t.upgrades.RangeAdd += upgradeRangeStep
+	t.recomputeStats()
+}
+
+// UpgradeFireRate shortens the time between the tower's shots.
+func (t *Tower) UpgradeFireRate() {
+	if t.upgrades.RateMult == 0 {
+		t.upgrades.RateMult = 1
+	}
+	t.upgrades.RateMult *= upgradeRateFactor
+	t.recomputeStats()
+}
+
+// UpgradeAmmoCapacity increases the tower's ammunition capacity. New slots
+// start loaded.
 func (t *Tower) UpgradeAmmoCapacity(increase int) {
 	if increase <= 0 {
 		return
 	}
+	t.upgrades.AmmoAdd += increase
+	t.recomputeStats()
+}
 
-	oldCapacity := t.ammoCapacity
-	t.ammoCapacity += increase
+// defaultProjectileProfile returns the projectile behavior a tower type
+// starts with.
+func defaultProjectileProfile(tt TowerType) ProjectileProfile {
+	switch tt {
+	case TowerSniper:
+		return ProjectileProfile{Behavior: ProjectilePierce, Pierce: 1}
+	case TowerRapid:
+		return ProjectileProfile{Behavior: ProjectileHoming, TurnRate: 3}
+	case TowerBallista:
+		return ProjectileProfile{Behavior: ProjectilePierce, Pierce: 2}
+	default:
+		return ProjectileProfile{Behavior: ProjectileStraight}
+	}
+}
 
-	// Expand ammo queue and fill new slots with ammo
-	newAmmoQueue := make([]bool, t.ammoCapacity)
-	copy(newAmmoQueue, t.ammoQueue)
-	for i := oldCapacity; i < t.ammoCapacity; i++ {
-		newAmmoQueue[i] = true // New slots start loaded
+// UpgradeProjectile improves the tower's projectile behavior. Straight shots
+// become splash shots and then mortar-like arcing shots; afterwards each
+// upgrade strengthens the current behavior.
+func (t *Tower) UpgradeProjectile() {
+	switch t.shot.Behavior {
+	case ProjectileStraight:
+		t.shot = ProjectileProfile{Behavior: ProjectileSplash, SplashRadius: 48}
+	case ProjectileSplash:
+		t.shot.Behavior = ProjectileArc
+		t.shot.SplashRadius += 16
+	case ProjectileArc:
+		t.shot.SplashRadius += 16
+	case ProjectilePierce:
+		t.shot.Pierce++
+	case ProjectileHoming:
+		t.shot.TurnRate += 2
 	}
-	t.ammoQueue = newAmmoQueue
 }
 
+// AntiAir reports whether the tower can target flying enemies.
+func (t *Tower) AntiAir() bool {
+	switch t.towerType {
+	case TowerSniper, TowerRapid, TowerBallista:
+		return true
+	}
+	return false
+}
+
+// SetProjectileProfile replaces the tower's projectile behavior.
+func (t *Tower) SetProjectileProfile(p ProjectileProfile) { t.shot = p }
+
+// ProjectileProfile returns the tower's current projectile behavior.
+func (t *Tower) ProjectileProfile() ProjectileProfile { return t.shot }
+
 // UpgradeForesight increases how many reload letters are previewed
 func (t *Tower) UpgradeForesight(increase int) {
 	if increase <= 0 {
 		return
 	}
-	t.foresight += increase
-	if t.foresight > 10 {
-		t.foresight = 10
-	}
+	t.upgrades.ForesightAdd += increase
+	t.recomputeStats()
 }
 
 // Draw renders the tower and its range indicator.
@@ -567,8 +529,35 @@ func (t *Tower) Draw(screen *ebiten.Image) {
 		screen.DrawImage(t.rangeImg, op)
 	}
 	t.BaseEntity.Draw(screen)
+	t.drawHealthBar(screen)
 }
 
+// drawHealthBar renders the tower's remaining health above it once damaged.
+func (t *Tower) drawHealthBar(screen *ebiten.Image) {
+	if t.hp >= t.maxHP || t.maxHP <= 0 {
+		return
+	}
+	w := float32(t.width)
+	x := float32(t.pos.X) - w/2
+	y := float32(t.pos.Y-t.frameAnchorY) - 6
+	vector.DrawFilledRect(screen, x, y, w, 4, color.RGBA{80, 0, 0, 255}, false)
+	vector.DrawFilledRect(screen, x, y, w*float32(t.hp)/float32(t.maxHP), 4, color.RGBA{0, 200, 0, 255}, false)
+}
+
+// Damage reduces the tower's health; at zero the tower is destroyed.
+func (t *Tower) Damage(amount int) {
+	t.hp -= amount
+	if t.hp < 0 {
+		t.hp = 0
+	}
+}
+
+// Alive reports whether the tower is still standing.
+func (t *Tower) Alive() bool { return t.hp > 0 }
+
+// Health returns the tower's remaining and maximum hit points.
+func (t *Tower) Health() (int, int) { return t.hp, t.maxHP }
+
 // generateRangeImage creates a semi-transparent circle representing the tower's range.
 func generateRangeImage(radius float64) *ebiten.Image {
 	r := int(radius)
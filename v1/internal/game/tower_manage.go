@@ -0,0 +1,230 @@
+package game
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// Keys typed in tower selection mode before a tower label to pick an action.
+const (
+	towerActionSell = '$' // sell the labelled tower for a partial refund
+	towerActionMove = '>' // relocate the labelled tower
+	towerActionUndo = '<' // undo the last build while the shop is open
+)
+
+// towerMove tracks an in-progress tower relocation. The destination is chosen
+// with the build cursor and confirmed with Enter, after which the relocation
+// word must be typed to complete the move.
+type towerMove struct {
+	tower  *Tower
+	typing bool   // destination confirmed, waiting for the word
+	word   string // relocation word the player must type
+	typed  int    // letters of word typed so far
+}
+
+// validateTowerManagement rejects refund shares outside 0-100% and negative
+// relocation costs.
+func validateTowerManagement(cfg Config) error {
+	if cfg.TowerRefundPercent < 0 || cfg.TowerRefundPercent > 100 {
+		return fmt.Errorf("tower_refund_percent %d is not between 0 and 100", cfg.TowerRefundPercent)
+	}
+	if cfg.TowerMoveCost < 0 {
+		return fmt.Errorf("tower_move_cost %d is negative", cfg.TowerMoveCost)
+	}
+	return nil
+}
+
+// towerMoveCost returns the gold paid to relocate a tower.
+func (g *Game) towerMoveCost() int {
+	if g.cfg == nil {
+		return DefaultConfig.TowerMoveCost
+	}
+	return g.cfg.TowerMoveCost
+}
+
+// towerRefund returns the resources returned when selling the given tower.
+// A configured 0% refund returns nothing.
+func (g *Game) towerRefund(t *Tower) Cost {
+	pct := DefaultConfig.TowerRefundPercent
+	if g.cfg != nil {
+		pct = g.cfg.TowerRefundPercent
+	}
+	return t.invested.Percent(pct)
+}
+
+// handleTowerSelectKey processes one typed rune in tower selection mode. It
+// returns true when the rune completed an action and selection mode ended.
+func (g *Game) handleTowerSelectKey(r rune) bool {
+	switch r {
+	case towerActionSell, towerActionMove:
+		g.towerAction = r
+		return false
+	case towerActionUndo:
+		g.UndoLastBuild()
+		g.towerSelectMode = false
+		return true
+	}
+	idx, ok := g.towerLabels[string(unicode.ToLower(r))]
+	if !ok {
+		return false
+	}
+	action := g.towerAction
+	g.towerAction = 0
+	g.towerSelectMode = false
+	switch action {
+	case towerActionSell:
+		g.SellTower(idx)
+	case towerActionMove:
+		g.beginTowerMove(idx)
+	default:
+		g.selectedTower = idx
+		g.upgradeMenuOpen = true
+		g.upgradeCursor = 0
+	}
+	return true
+}
+
+// SellTower removes the tower at idx and refunds part of the resources
+// invested in it. It returns false if idx is out of range.
+func (g *Game) SellTower(idx int) bool {
+	if idx < 0 || idx >= len(g.towers) {
+		return false
+	}
+	g.resources.Refund(g.towerRefund(g.towers[idx]))
+	g.removeTower(idx)
+	return true
+}
+
+// UndoLastBuild removes the most recently built tower with a full refund. It
+// only works while the shop is open.
+func (g *Game) UndoLastBuild() bool {
+	if !g.shopOpen || g.lastBuilt == nil {
+		return false
+	}
+	for i, t := range g.towers {
+		if t == g.lastBuilt {
+			g.resources.Refund(t.invested)
+			g.removeTower(i)
+			return true
+		}
+	}
+	g.lastBuilt = nil
+	return false
+}
+
+// removeTower deletes the tower at idx, drops its queued repair word and
+// keeps the selected tower index and selection labels consistent with the
+// shortened tower list.
+func (g *Game) removeTower(idx int) {
+	t := g.towers[idx]
+	g.towers = append(g.towers[:idx], g.towers[idx+1:]...)
+	if t.repairWord != "" && g.queue != nil {
+		g.queue.Remove(Word{Text: t.repairWord, Source: repairWordSource, Family: repairWordFamily})
+	}
+	if g.lastBuilt == t {
+		g.lastBuilt = nil
+	}
+	if g.moving != nil && g.moving.tower == t {
+		g.moving = nil
+	}
+	switch {
+	case len(g.towers) == 0:
+		g.selectedTower = 0
+	case g.selectedTower > idx:
+		g.selectedTower--
+	case g.selectedTower >= len(g.towers):
+		g.selectedTower = len(g.towers) - 1
+	}
+	if g.towerSelectMode {
+		g.enterTowerSelectMode()
+	} else {
+		g.towerLabels = make(map[string]int)
+	}
+	g.applySynergies()
+	g.invalidatePaths()
+}
+
+// beginTowerMove starts relocating the tower at idx, placing the build cursor
+// on its current tile.
+func (g *Game) beginTowerMove(idx int) {
+	if idx < 0 || idx >= len(g.towers) {
+		return
+	}
+	t := g.towers[idx]
+	g.selectedTower = idx
+	g.cursorX, g.cursorY = towerTile(t)
+	word := g.randomChallengeWord()
+	if word == "" {
+		word = "move"
+	}
+	g.moving = &towerMove{tower: t, word: word}
+}
+
+// updateTowerMove handles input while a tower is being relocated. The
+// destination is only accepted while the move cost can be paid, and the cost
+// is paid when the word is typed. Backspace cancels the move.
+func (g *Game) updateTowerMove() {
+	m := g.moving
+	if g.input.Backspace() {
+		g.moving = nil
+		return
+	}
+	if !m.typing {
+		g.moveCursor()
+		if g.input.Enter() && g.validTowerPosition(g.cursorX, g.cursorY) && g.Gold() >= g.towerMoveCost() {
+			m.typing = true
+			m.typed = 0
+		}
+		return
+	}
+	for _, r := range g.input.TypedChars() {
+		if unicode.ToLower(r) == rune(m.word[m.typed]) {
+			m.typed++
+			g.typing.Record(true)
+		} else {
+			m.typed = 0
+			g.typing.Record(false)
+			g.MistypeFeedback()
+		}
+		if m.typed >= len(m.word) {
+			g.moving = nil
+			if !g.SpendGold(g.towerMoveCost()) {
+				return
+			}
+			tx, ty := tilePosition(g.cursorX, g.cursorY)
+			m.tower.pos = Point{float64(tx + TileSize/2), float64(ty + TileSize/2)}
+			g.applySynergies()
+			g.invalidatePaths()
+			return
+		}
+	}
+}
+
+// moveCursor moves the build cursor with the direction keys, clamped to the
+// tile grid.
+func (g *Game) moveCursor() {
+	if g.input.Left() {
+		g.cursorX--
+	}
+	if g.input.Right() {
+		g.cursorX++
+	}
+	if g.input.Up() {
+		g.cursorY--
+	}
+	if g.input.Down() {
+		g.cursorY++
+	}
+	if g.cursorX < 0 {
+		g.cursorX = 0
+	}
+	if g.cursorX > 59 {
+		g.cursorX = 59
+	}
+	if g.cursorY < 0 {
+		g.cursorY = 0
+	}
+	if g.cursorY > 33 {
+		g.cursorY = 33
+	}
+}
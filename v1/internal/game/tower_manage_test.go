@@ -0,0 +1,149 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSellTowerRefundsAndKeepsSelection(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10)}
+	a := NewTower(g, 100, 100)
+	b := NewTower(g, 200, 200)
+	c := NewTower(g, 300, 300)
+	a.invested, b.invested, c.invested = Cost{Gold: 20, Wood: 2}, Cost{Gold: 30}, Cost{Gold: 40}
+	g.towers = []*Tower{a, b, c}
+	g.selectedTower = 2
+
+	if !g.SellTower(0) {
+		t.Fatalf("sell should succeed")
+	}
+	if g.Gold() != 10 || g.resources.WoodAmount() != 1 {
+		t.Errorf("expected 50%% refund of 20G 2W got %dG %dW", g.Gold(), g.resources.WoodAmount())
+	}
+	if len(g.towers) != 2 || g.towers[g.selectedTower] != c {
+		t.Errorf("selected tower should still point at the same tower")
+	}
+	g.selectedTower = 1
+	g.SellTower(1)
+	if g.selectedTower != 0 {
+		t.Errorf("selection should clamp after removing the last tower got %d", g.selectedTower)
+	}
+}
+
+func TestTowerSelectSellRelabels(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10)}
+	g.towers = []*Tower{NewTower(g, 100, 100), NewTower(g, 200, 200)}
+	g.enterTowerSelectMode()
+	g.handleTowerSelectKey(towerActionSell)
+	if !g.handleTowerSelectKey('a') {
+		t.Fatalf("label should complete the sell action")
+	}
+	if len(g.towers) != 1 {
+		t.Fatalf("expected one tower left got %d", len(g.towers))
+	}
+	g.enterTowerSelectMode()
+	if idx, ok := g.towerLabels["a"]; !ok || idx != 0 || len(g.towerLabels) != 1 {
+		t.Errorf("labels should be reassigned after removal: %v", g.towerLabels)
+	}
+}
+
+func TestUndoLastBuildOnlyInShop(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10)}
+	g.resources.Refund(g.towerCost(TowerBasic))
+	g.cursorX, g.cursorY = 10, 10
+	g.buildTowerAtCursorType(TowerBasic)
+	if len(g.towers) != 1 || g.Gold() != 0 || g.resources.StoneAmount() != 0 {
+		t.Fatalf("build should spend gold and stone")
+	}
+	if g.UndoLastBuild() {
+		t.Fatalf("undo should require the shop phase")
+	}
+	g.shopOpen = true
+	if !g.UndoLastBuild() {
+		t.Fatalf("undo should succeed in the shop")
+	}
+	if len(g.towers) != 0 || !g.resources.CanAfford(g.towerCost(TowerBasic)) {
+		t.Errorf("undo should remove the tower with a full refund")
+	}
+}
+
+func TestTowerMoveRequiresTypedWord(t *testing.T) {
+	inp := &stubInput{}
+	g := &Game{cfg: &DefaultConfig, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10)}
+	g.input = inp
+	tx, ty := tilePosition(3, 3)
+	tower := NewTower(g, float64(tx+TileSize/2), float64(ty+TileSize/2))
+	g.towers = []*Tower{tower}
+	g.AddGold(g.towerMoveCost())
+	g.beginTowerMove(0)
+	g.cursorX, g.cursorY = 8, 8
+	g.moving.typing = true
+	inp.typed = []rune(g.moving.word)
+	g.updateTowerMove()
+	if g.moving != nil {
+		t.Fatalf("move should complete after typing the word")
+	}
+	if x, y := towerTile(tower); x != 8 || y != 8 {
+		t.Errorf("tower should be on tile 8,8 got %d,%d", x, y)
+	}
+	if g.Gold() != 0 {
+		t.Errorf("the move should cost %d gold, %d left", g.towerMoveCost(), g.Gold())
+	}
+}
+
+// enterInput is a stubInput that holds Enter down.
+type enterInput struct{ stubInput }
+
+func (*enterInput) Enter() bool { return true }
+
+func TestTowerMoveNeedsGold(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10)}
+	g.input = &enterInput{}
+	tx, ty := tilePosition(3, 3)
+	g.towers = []*Tower{NewTower(g, float64(tx+TileSize/2), float64(ty+TileSize/2))}
+	g.beginTowerMove(0)
+	g.cursorX, g.cursorY = 8, 8
+	g.updateTowerMove()
+	if g.moving.typing {
+		t.Fatalf("a move the player cannot pay for should not be confirmed")
+	}
+	g.AddGold(g.towerMoveCost())
+	g.updateTowerMove()
+	if !g.moving.typing {
+		t.Errorf("the move should be confirmed once it can be paid")
+	}
+}
+
+func TestZeroRefundIsHonoured(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.TowerRefundPercent = 0
+	g := &Game{cfg: &cfg, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10)}
+	tower := NewTower(g, 100, 100)
+	tower.invested = Cost{Gold: 20}
+	g.towers = []*Tower{tower}
+	g.SellTower(0)
+	if g.Gold() != 0 {
+		t.Errorf("a 0%% refund should return nothing, got %dG", g.Gold())
+	}
+	path := filepath.Join(t.TempDir(), "cfg.json")
+	for _, data := range []string{`{"tower_refund_percent": 150}`, `{"tower_refund_percent": -1}`, `{"tower_move_cost": -5}`} {
+		os.WriteFile(path, []byte(data), 0644)
+		if _, err := LoadConfig(path); err == nil {
+			t.Errorf("%s should be rejected", data)
+		}
+	}
+}
+
+func TestSoldTowerDropsRepairWord(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: &stubInput{}, typing: NewTypingStats(), towerLabels: map[string]int{}, base: NewBase(0, 0, 10), queue: NewQueueManager()}
+	g.towers = []*Tower{NewTower(g, 100, 100)}
+	g.damageTower(g.towers[0], 1)
+	if g.queue.Len() != 1 {
+		t.Fatalf("a damaged tower should queue its repair word")
+	}
+	g.SellTower(0)
+	if g.queue.Len() != 0 {
+		t.Errorf("selling the tower should drop its repair word")
+	}
+}
@@ -251,3 +251,28 @@ func TestNewTowerTypes(t *testing.T) {
 		t.Errorf("rapid tower should fire faster")
 	}
 }
+
+func TestAntiAirTargeting(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig, input: NewInput(), typing: NewTypingStats()}
+	b := NewBase(0, 0, 10)
+	flyer := NewFlyingMob(100, 0, b, 3, 1)
+	ground := NewMob(100, 0, b, 3, 1)
+	g.mobs = []Enemy{flyer, ground}
+	for tt, want := range map[TowerType]bool{TowerBasic: false, TowerBanner: false, TowerSniper: true, TowerRapid: true, TowerBallista: true} {
+		tower := NewTowerWithType(g, 0, 0, tt)
+		if tower.AntiAir() != want || canHit(flyer, tower.AntiAir()) != want {
+			t.Errorf("tower type %d anti-air should be %v", tt, want)
+		}
+		if !canHit(ground, tower.AntiAir()) {
+			t.Errorf("tower type %d should hit ground enemies", tt)
+		}
+	}
+	p := NewProjectile(g, 0, 0, ground, 1, 1, 0)
+	if c := p.candidates(); len(c) != 1 || c[0] != ground {
+		t.Errorf("ground-only projectile should ignore flyers, got %d candidates", len(c))
+	}
+	p.antiAir = true
+	if len(p.candidates()) != 2 {
+		t.Errorf("anti-air projectile should consider flyers")
+	}
+}
@@ -0,0 +1,256 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// meleeInterval is the number of seconds between blows a unit trades with
+// the enemies touching it.
+const meleeInterval = 0.5
+
+// Unit is a player-controlled soldier managed by Military. Every unit shares
+// the rally, aggro and separation behaviour of its soldier body and differs
+// in how it fights.
+type Unit interface {
+	Entity
+	Alive() bool
+	Damage(amount int)
+	Health() int
+	Kind() string // roster id, e.g. UnitArcher
+	SetRally(p Point, stance Stance)
+	Rally() (Point, Stance)
+
+	body() *soldier              // shared movement and targeting state
+	melee(o foe)                 // hits an enemy touching the unit
+	act(m *Military, dt float64) // ranged shot or heal, if any
+}
+
+// foe is an enemy a unit can fight: a grunt, or a boss it was ordered to
+// focus.
+type foe interface {
+	Alive() bool
+	Damage(amount int)
+	Position() (x, y float64)
+	Hitbox() (x, y, width, height int)
+}
+
+// soldier holds the state every unit shares: stats from the roster, its
+// target and its rally orders.
+type soldier struct {
+	BaseEntity
+	kind     string
+	hp       int     // current hit points
+	maxHP    int     // hit points healers restore up to
+	damage   int     // damage dealt per hit or shot
+	armor    int     // damage ignored per hit
+	speed    float64 // movement speed in pixels/sec
+	reach    float64 // distance kept from the target, 0 for melee
+	interval CooldownTimer
+	swing    CooldownTimer // time until the next melee exchange
+	supply   int           // supply taken and Food eaten per wave
+	morale   float64       // damage multiplier from the army's morale
+	alive    bool          // whether the unit is active
+	target   foe
+	focus    foe // ordered target that overrides the nearest grunt
+
+	rally      Point   // where the unit returns when idle
+	stance     Stance  // how far the unit strays from rally
+	sepX, sepY float64 // separation push from nearby units
+}
+
+// newSoldier creates a soldier at the given position with the roster stats.
+// It rallies where it was created until ordered elsewhere.
+func newSoldier(s UnitStats, img *ebiten.Image, x, y float64) soldier {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	return soldier{
+		BaseEntity: BaseEntity{
+			pos:          Point{x, y},
+			width:        w,
+			height:       h,
+			frame:        img,
+			frameAnchorX: float64(w) / 2,
+			frameAnchorY: float64(h) / 2,
+		},
+		kind:     s.ID,
+		hp:       s.HP,
+		maxHP:    s.HP,
+		damage:   s.Damage,
+		armor:    s.Armor,
+		speed:    s.Speed,
+		reach:    s.Range,
+		interval: NewCooldownTimer(s.Interval),
+		swing:    CooldownTimer{interval: meleeInterval},
+		supply:   s.Supply,
+		morale:   1,
+		alive:    true,
+		rally:    Point{x, y},
+	}
+}
+
+// NewUnit creates the unit described by the roster stats at the given
+// position.
+func NewUnit(s UnitStats, x, y float64) Unit {
+	switch s.ID {
+	case UnitArcher:
+		return &Archer{soldier: newSoldier(s, ImgArcherUnit, x, y)}
+	case UnitKnight:
+		return &Knight{soldier: newSoldier(s, ImgKnight, x, y)}
+	case UnitHealer:
+		return &Healer{soldier: newSoldier(s, ImgHealer, x, y), allyHeal: s.Heal, baseHeal: s.BaseHeal}
+	}
+	return &Footman{soldier: newSoldier(s, ImgFootman, x, y)}
+}
+
+// Update moves the unit towards its target grunt, stopping at its reach, or
+// back to its rally point when it has none, pushed apart from nearby units,
+// and checks if it is still alive.
+func (s *soldier) Update(dt float64) error {
+	if !s.alive {
+		return nil
+	}
+	chasing := s.target != nil && s.target.Alive()
+	goal := s.rally
+	if chasing {
+		goal.X, goal.Y = s.target.Position()
+	}
+	maxStep := s.speed * dt
+	mx, my := 0.0, 0.0
+	dx, dy := goal.X-s.pos.X, goal.Y-s.pos.Y
+	dist := math.Hypot(dx, dy)
+	if chasing {
+		dist -= s.reach
+	}
+	if dist > 0 && (chasing || dist > footmanArrive) {
+		step := math.Min(maxStep, dist)
+		d := math.Hypot(dx, dy)
+		mx, my = dx/d*step, dy/d*step
+	}
+	mx += s.sepX * maxStep
+	my += s.sepY * maxStep
+	if d := math.Hypot(mx, my); d > maxStep {
+		mx, my = mx/d*maxStep, my/d*maxStep
+	}
+	s.pos.X += mx
+	s.pos.Y += my
+	if s.hp <= 0 {
+		s.alive = false
+	}
+	return nil
+}
+
+func (s *soldier) body() *soldier { return s }
+
+// SetRally moves the unit's rally point and stance.
+func (s *soldier) SetRally(p Point, stance Stance) {
+	s.rally = p
+	s.stance = stance
+}
+
+// Rally returns the unit's rally point and stance.
+func (s *soldier) Rally() (Point, Stance) { return s.rally, s.stance }
+
+// Kind returns the unit's roster id.
+func (s *soldier) Kind() string { return s.kind }
+
+// Alive reports whether the unit is still active.
+func (s *soldier) Alive() bool { return s.alive }
+
+// Damage reduces the unit's HP. Armor soaks part of every hit but at least
+// one point gets through.
+func (s *soldier) Damage(amount int) {
+	if !s.alive {
+		return
+	}
+	if s.armor > 0 && amount > 0 {
+		amount = max(1, amount-s.armor)
+	}
+	s.hp -= amount
+	if s.hp <= 0 {
+		s.alive = false
+	}
+}
+
+// heal restores hit points up to the unit's maximum.
+func (s *soldier) heal(amount int) {
+	if s.alive {
+		s.hp = min(s.hp+amount, s.maxHP)
+	}
+}
+
+// Health returns the unit's current HP.
+func (s *soldier) Health() int { return s.hp }
+
+// Frame satisfies the Entity interface for units.
+func (s *soldier) Frame() *ebiten.Image { return s.frame }
+
+// hit returns the unit's damage after morale.
+func (s *soldier) hit() int { return int(math.Round(float64(s.damage) * s.morale)) }
+
+// strikeMelee hits a touching enemy with the unit's damage.
+func (s *soldier) strikeMelee(o foe) { o.Damage(s.hit()) }
+
+// Knight is a slow, armored melee unit.
+type Knight struct{ soldier }
+
+func (k *Knight) melee(o foe)                 { k.strikeMelee(o) }
+func (k *Knight) act(m *Military, dt float64) {}
+
+// Archer keeps its distance and shoots its target from range.
+type Archer struct{ soldier }
+
+// melee does nothing: archers only fight from range.
+func (a *Archer) melee(o foe) {}
+
+// act shoots the target once it is within range and the bow is ready.
+func (a *Archer) act(m *Military, dt float64) {
+	t := a.target
+	if !a.interval.Tick(dt) || t == nil || !t.Alive() {
+		return
+	}
+	if x, y := t.Position(); math.Hypot(x-a.pos.X, y-a.pos.Y) > a.reach {
+		return
+	}
+	t.Damage(a.hit())
+	a.interval.Reset()
+}
+
+// Healer does not fight; it restores the most injured ally in range and the
+// base when it stands close enough.
+type Healer struct {
+	soldier
+	allyHeal int // hit points restored to an ally per heal
+	baseHeal int // base hit points restored per heal
+}
+
+// melee does nothing: healers do not fight.
+func (h *Healer) melee(o foe) {}
+
+// act heals once the interval is up and someone in range is hurt.
+func (h *Healer) act(m *Military, dt float64) {
+	if !h.interval.Tick(dt) {
+		return
+	}
+	var worst *soldier
+	for _, u := range m.units {
+		s := u.body()
+		if !s.alive || s.hp >= s.maxHP || math.Hypot(s.pos.X-h.pos.X, s.pos.Y-h.pos.Y) > h.reach {
+			continue
+		}
+		if worst == nil || s.maxHP-s.hp > worst.maxHP-worst.hp {
+			worst = s
+		}
+	}
+	healed := false
+	if worst != nil && h.allyHeal > 0 {
+		worst.heal(h.allyHeal)
+		healed = true
+	}
+	if b := m.base; b != nil && h.baseHeal > 0 && math.Hypot(b.pos.X-h.pos.X, b.pos.Y-h.pos.Y) <= h.reach && b.Heal(h.baseHeal) {
+		healed = true
+	}
+	if healed {
+		h.interval.Reset()
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import "testing"
+
+// rosterUnit creates a unit from the default roster.
+func rosterUnit(t *testing.T, id string, x, y float64) Unit {
+	t.Helper()
+	s := DefaultRoster().Stats(id)
+	if s == nil {
+		t.Fatalf("no unit %s", id)
+	}
+	return NewUnit(*s, x, y)
+}
+
+func TestArcherShootsFromRange(t *testing.T) {
+	m := NewMilitary()
+	a := rosterUnit(t, UnitArcher, 0, 0)
+	m.AddUnit(a)
+	o := NewOrcGrunt(150, 0)
+	o.speed = 0
+	hp := o.hp
+	for i := 0; i < 30; i++ {
+		m.Update(0.1, []*OrcGrunt{o})
+	}
+	if o.hp >= hp {
+		t.Errorf("archer should have shot the grunt")
+	}
+	if x, _ := a.Position(); x > 1 {
+		t.Errorf("archer should keep its distance, moved to x=%.1f", x)
+	}
+}
+
+func TestKnightArmorSoaksDamage(t *testing.T) {
+	k := rosterUnit(t, UnitKnight, 0, 0)
+	k.Damage(3)
+	if k.Health() != 18 {
+		t.Errorf("armor should soak 1 of 3 damage, hp %d", k.Health())
+	}
+	k.Damage(1)
+	if k.Health() != 17 {
+		t.Errorf("at least one point should get through armor, hp %d", k.Health())
+	}
+}
+
+func TestHealerRestoresAlliesAndBase(t *testing.T) {
+	m := NewMilitary()
+	base := NewBase(0, 0, 10)
+	base.Damage(3)
+	m.SetBase(base)
+	f := NewFootman(10, 0)
+	f.speed = 0
+	f.Damage(5)
+	h := rosterUnit(t, UnitHealer, 0, 10)
+	h.body().speed = 0
+	m.AddUnit(f)
+	m.AddUnit(h)
+	m.Update(3, nil)
+	if f.Health() != 7 {
+		t.Errorf("healer should restore 2 hp to the footman, hp %d", f.Health())
+	}
+	if base.Health() != 8 {
+		t.Errorf("healer should restore 1 base hp, hp %d", base.Health())
+	}
+	for i := 0; i < 10; i++ {
+		m.Update(3, nil)
+	}
+	if f.Health() != 10 || base.Health() != 10 {
+		t.Errorf("healing should stop at full health, footman %d base %d", f.Health(), base.Health())
+	}
+}
+
+func TestHealerDoesNotChase(t *testing.T) {
+	m := NewMilitary()
+	h := rosterUnit(t, UnitHealer, 0, 0)
+	m.AddUnit(h)
+	o := NewOrcGrunt(100, 0)
+	o.speed = 0
+	m.Update(0.1, []*OrcGrunt{o})
+	if h.body().target != nil {
+		t.Errorf("healers should not pick a target")
+	}
+}
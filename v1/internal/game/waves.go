@@ -0,0 +1,389 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// WavesFile is the default path for wave script data.
+const WavesFile = "waves.yaml"
+
+// waveRows is the number of tile rows mobs can spawn on.
+const waveRows = 32
+
+// mobKinds maps wave script mob names to mob types. "mixed" is handled
+// separately and picks a random regular mob for every spawn.
+var mobKinds = map[string]MobType{
+	"basic":    MobBasic,
+	"armored":  MobArmored,
+	"fast":     MobFast,
+	"shielded": MobShielded,
+	"word":     MobWord,
+	"boss":     MobBoss,
+	"grunt":    MobGrunt,
+	"archer":   MobArcher,
+	"shaman":   MobShaman,
+	"catapult": MobCatapult,
+	"flyer":    MobFlyer,
+}
+
+// gruntSquad is the size of the orc grunt squads in the default waves.
+const gruntSquad = 2
+
+// mobMixed names a group that spawns a random regular mob each time.
+const mobMixed = "mixed"
+
+// mixedMobs are the regular mob types a mixed group picks from.
+var mixedMobs = []MobType{MobBasic, MobArmored, MobFast, MobShielded}
+
+// WaveModifiers adjusts every mob spawned by a group.
+type WaveModifiers struct {
+	Armor  int     `yaml:"armor"`
+	Shield int     `yaml:"shield"`
+	Burst  float64 `yaml:"burst"`
+}
+
+// WaveGroup is a run of identical spawns within a wave. Groups spawn one after
+// another in the order they are listed.
+type WaveGroup struct {
+	Mob        string        `yaml:"mob"`
+	Count      int           `yaml:"count"`
+	HPScale    float64       `yaml:"hp_scale"`    // multiplier on wave health, 0 means 1
+	SpeedScale float64       `yaml:"speed_scale"` // multiplier on mob speed, 0 means 1
+	Lane       string        `yaml:"lane"`        // map lane to follow, random row when omitted
+	Spacing    float64       `yaml:"spacing"`     // seconds between spawns, 0 uses the configured interval
+	Delay      float64       `yaml:"delay"`       // extra seconds before the group's first spawn
+	LoopGrowth int           `yaml:"loop_growth"` // extra mobs per endless loop
+	Boss       string        `yaml:"boss"`        // boss definition for boss groups, first boss when omitted
+	Modifiers  WaveModifiers `yaml:"modifiers"`
+}
+
+// WaveDef describes a single wave.
+type WaveDef struct {
+	Name   string      `yaml:"name"`
+	Groups []WaveGroup `yaml:"groups"`
+}
+
+// WaveLoop controls how waves repeat once the script runs out.
+type WaveLoop struct {
+	From     int     `yaml:"from"`      // 1-based wave to restart from
+	HPGrowth float64 `yaml:"hp_growth"` // added health multiplier per loop
+}
+
+// WaveScript is an ordered list of waves loaded from YAML.
+type WaveScript struct {
+	Waves []WaveDef `yaml:"waves"`
+	Loop  WaveLoop  `yaml:"loop"`
+}
+
+// LoadWaveScript parses a YAML file into a WaveScript and validates it.
+func LoadWaveScript(path string) (*WaveScript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWaveScript(data)
+}
+
+// ParseWaveScript parses YAML wave data and validates it.
+func ParseWaveScript(data []byte) (*WaveScript, error) {
+	var s WaveScript
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, err
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// validate checks mob names, counts and ranges and fills in the loop start.
+func (s *WaveScript) validate() error {
+	if len(s.Waves) == 0 {
+		return fmt.Errorf("wave script has no waves")
+	}
+	for i, w := range s.Waves {
+		if len(w.Groups) == 0 {
+			return fmt.Errorf("wave %d has no groups", i+1)
+		}
+		for j, g := range w.Groups {
+			where := fmt.Sprintf("wave %d group %d", i+1, j+1)
+			if _, ok := mobKinds[g.Mob]; !ok && g.Mob != mobMixed {
+				return fmt.Errorf("%s: unknown mob %q", where, g.Mob)
+			}
+			if g.Count < 1 {
+				return fmt.Errorf("%s: count must be at least 1", where)
+			}
+			if g.HPScale < 0 || g.SpeedScale < 0 || g.Spacing < 0 || g.Delay < 0 || g.LoopGrowth < 0 {
+				return fmt.Errorf("%s: negative value", where)
+			}
+			if g.Modifiers.Armor < 0 || g.Modifiers.Shield < 0 || g.Modifiers.Burst < 0 {
+				return fmt.Errorf("%s: negative modifier", where)
+			}
+		}
+	}
+	if s.Loop.From == 0 {
+		s.Loop.From = 1
+	}
+	if s.Loop.From < 1 || s.Loop.From > len(s.Waves) {
+		return fmt.Errorf("loop from %d outside 1-%d", s.Loop.From, len(s.Waves))
+	}
+	if s.Loop.HPGrowth < 0 {
+		return fmt.Errorf("negative loop hp growth")
+	}
+	return nil
+}
+
+// Wave returns the definition for the 1-based wave number n and how many times
+// the script has looped to reach it.
+func (s *WaveScript) Wave(n int) (WaveDef, int) {
+	if n < 1 {
+		n = 1
+	}
+	idx := n - 1
+	if idx < len(s.Waves) {
+		return s.Waves[idx], 0
+	}
+	start := s.Loop.From - 1
+	span := len(s.Waves) - start
+	over := idx - len(s.Waves)
+	return s.Waves[start+over%span], over/span + 1
+}
+
+// DefaultWaveScript returns the built-in waves. Mob counts grow by the
+// configured increment every wave, orc squads join from the second wave,
+// ranged and flying attackers from the fourth and every fifth wave ends with a
+// boss.
+func DefaultWaveScript(cfg Config) *WaveScript {
+	base := cfg.MobsPerWave
+	if base == 0 {
+		base = DefaultConfig.MobsPerWave
+	}
+	inc := cfg.MobsPerWaveInc
+	s := &WaveScript{Loop: WaveLoop{From: 1}}
+	for i := 0; i < 5; i++ {
+		count := base + inc*i
+		grp := WaveGroup{Mob: mobMixed, Count: count, LoopGrowth: 5 * inc}
+		w := WaveDef{Name: fmt.Sprintf("Wave %d", i+1), Groups: []WaveGroup{grp}}
+		// divert moves n mobs out of the mixed group, which keeps at least one
+		divert := func(n int) { w.Groups[0].Count = max(1, w.Groups[0].Count-n) }
+		if i >= 1 && w.Groups[0].Count > gruntSquad {
+			// From the second wave an orc squad marches in close order
+			divert(gruntSquad)
+			w.Groups = append(w.Groups, WaveGroup{Mob: "grunt", Count: gruntSquad, Spacing: 0.5})
+		}
+		if i >= 2 {
+			// From the third wave one mob carries a word to type out
+			divert(1)
+			w.Groups = append(w.Groups, WaveGroup{Mob: "word", Count: 1})
+		}
+		if i >= 3 {
+			// Archers start picking at the defenses and a flyer tests the
+			// anti-air, backed by a catapult in the boss wave
+			divert(2)
+			w.Groups = append(w.Groups, WaveGroup{Mob: "archer", Count: 1}, WaveGroup{Mob: "flyer", Count: 1})
+		}
+		if i == 4 {
+			divert(2)
+			w.Groups = append(w.Groups, WaveGroup{Mob: "catapult", Count: 1})
+			w.Groups = append(w.Groups, WaveGroup{Mob: "boss", Count: 1, HPScale: 5, SpeedScale: 0.5, Boss: "warlord"})
+		}
+		s.Waves = append(s.Waves, w)
+	}
+	return s
+}
+
+// waveSpawn is a single pending spawn of the current wave.
+type waveSpawn struct {
+	group    WaveGroup
+	wave     int     // wave the spawn was scheduled for, 0 for the current one
+	wait     float64 // group delay paid before this spawn
+	hpScale  float64 // combined group and loop health multiplier
+	entry    [2]int  // tile the mob enters on, once announced
+	hasEntry bool
+}
+
+// interval returns the seconds to wait before this spawn.
+func (s waveSpawn) interval(def float64) float64 {
+	if s.group.Spacing > 0 {
+		return s.wait + s.group.Spacing
+	}
+	return s.wait + def
+}
+
+// SetWaveScript replaces the wave script and reschedules the current wave if
+// it has not started spawning yet.
+func (g *Game) SetWaveScript(s *WaveScript) {
+	if s == nil {
+		return
+	}
+	g.waveScript = s
+	if !g.waveStarted() {
+		g.scheduleWave()
+	}
+}
+
+// waveStarted reports whether any mob of the current wave has spawned.
+func (g *Game) waveStarted() bool {
+	return len(g.mobs) > 0 || len(g.pendingSpawns) < g.waveSize
+}
+
+// reloadWaves replaces the wave script from path. The new script takes
+// effect from the next wave. A script naming lanes or bosses the game does
+// not have is refused and the old script kept.
+func (g *Game) reloadWaves(path string) error {
+	s, err := LoadWaveScript(path)
+	if err != nil {
+		return err
+	}
+	if err := s.CheckLanes(g.gameMap); err != nil {
+		return err
+	}
+	if err := s.CheckBosses(g.bosses); err != nil {
+		return err
+	}
+	g.waveScript = s
+	return nil
+}
+
+// scheduleWave queues every spawn of the current wave.
+func (g *Game) scheduleWave() {
+	if g.waveScript == nil {
+		g.waveScript = DefaultWaveScript(*g.cfg)
+	}
+	def, loops := g.waveScript.Wave(g.currentWave)
+	loopScale := 1 + g.waveScript.Loop.HPGrowth*float64(loops)
+	g.pendingSpawns = g.pendingSpawns[:0]
+	for _, grp := range def.Groups {
+		scale := grp.HPScale
+		if scale == 0 {
+			scale = 1
+		}
+		n := grp.Count + grp.LoopGrowth*loops
+		for i := 0; i < n; i++ {
+			s := waveSpawn{group: grp, wave: g.currentWave, hpScale: scale * loopScale}
+			if i == 0 {
+				s.wait = grp.Delay
+			}
+			g.pendingSpawns = append(g.pendingSpawns, s)
+		}
+	}
+	g.waveSize = len(g.pendingSpawns)
+}
+
+// updateSpawns spawns the next pending mob once its interval has elapsed.
+func (g *Game) updateSpawns(dt float64) {
+	if len(g.pendingSpawns) == 0 {
+		return
+	}
+	g.spawnTicker += dt
+	next := g.pendingSpawns[0]
+	if g.spawnTicker >= next.interval(g.spawnInterval) {
+		g.spawnTicker = 0
+		g.pendingSpawns = g.pendingSpawns[1:]
+		g.spawnMob(next)
+	}
+	g.announceSpawns()
+}
+
+// entryTile picks the tile a mob of the group enters on: its lane's first
+// waypoint, a random open row of the right edge, or any edge row for flyers.
+func (g *Game) entryTile(grp WaveGroup) [2]int {
+	start := [2]int{gridCols - 1, rand.Intn(waveRows)}
+	if grp.Mob == "flyer" {
+		return start
+	}
+	if lane, ok := g.gameMap.Lane(grp.Lane); ok {
+		return lane.Waypoints[0]
+	}
+	if open := g.spawnTiles(g.blockedFunc(nil)); len(open) > 0 {
+		return open[rand.Intn(len(open))]
+	}
+	return start
+}
+
+// spawnMob creates the mob described by s on its announced entry tile, or on
+// a freshly picked one if it was never announced or has since been built on.
+// Flyers ignore lanes and walls. The mob is scaled for the wave it was
+// scheduled in, even if a later Endless wave has started.
+func (g *Game) spawnMob(s waveSpawn) {
+	wave := s.wave
+	if wave == 0 {
+		wave = g.currentWave
+	}
+	kind, ok := mobKinds[s.group.Mob]
+	if !ok {
+		kind = mixedMobs[rand.Intn(len(mixedMobs))]
+	}
+	start := s.entry
+	if !s.hasEntry || (kind != MobFlyer && g.blockedFunc(nil)(start[0], start[1])) {
+		start = g.entryTile(s.group)
+	}
+	var waypoints [][2]int
+	if lane, ok := g.gameMap.Lane(s.group.Lane); ok && kind != MobFlyer {
+		waypoints = lane.Waypoints[1:]
+	}
+	x, y := tilePosition(start[0], start[1])
+	hp := g.cfg.MobBaseHealth
+	if hp == 0 {
+		hp = 1
+	}
+	if kind == MobGrunt {
+		hp = gruntBaseHP
+	}
+	hp = int(float64(hp) + float64(wave-1)*g.cfg.N)
+	hp = int(float64(hp) * s.hpScale)
+	hp = int(scale(float64(hp), g.difficulty.MobHPMult))
+	endlessHP, endlessSpeed := g.endlessScale(wave)
+	hp = int(float64(hp) * endlessHP)
+	if hp < 1 {
+		hp = 1
+	}
+	speed := g.cfg.MobSpeed * 0.3 // Much slower mobs
+	if speed == 0 {
+		speed = DefaultConfig.MobSpeed * 0.3
+	}
+	if s.group.SpeedScale > 0 {
+		speed *= s.group.SpeedScale
+	}
+	speed = scale(speed, g.difficulty.MobSpeedMult) * endlessSpeed
+	if kind == MobGrunt {
+		o := g.spawnGrunt(float64(x+16), float64(y+16), hp, waypoints)
+		if s.group.SpeedScale > 0 {
+			o.speed *= s.group.SpeedScale
+		}
+		o.speed = scale(o.speed, g.difficulty.MobSpeedMult) * endlessSpeed
+		return
+	}
+	var m *Mob
+	var enemy Enemy
+	if def, ok := g.bosses.Boss(s.group.Boss); kind == MobBoss && ok {
+		b := NewBoss(g, def, float64(x+16), float64(y+16), hp, speed)
+		m, enemy = b.Mob, b
+	} else if kind == MobWord {
+		m = NewWordMob(float64(x+16), float64(y+16), g.base, g.mobWord(), speed)
+	} else {
+		m = newMobOfType(kind, float64(x+16), float64(y+16), g.base, hp, speed)
+		m.applyAffixes(g.affixes.RollAffixes(wave, g.affixScale))
+	}
+	m.armor += s.group.Modifiers.Armor
+	m.shield += s.group.Modifiers.Shield
+	if m.maxShield > 0 {
+		m.maxShield = m.shield
+	}
+	if s.group.Modifiers.Burst > 0 {
+		m.burst += s.group.Modifiers.Burst
+		m.burstTimer = NewCooldownTimer(4.0)
+		m.burstActive = NewCooldownTimer(1.0)
+		m.burstActive.remaining = 0
+	}
+	m.route.waypoints = waypoints
+	g.routeMob(m)
+	if enemy == nil {
+		enemy = m
+	}
+	g.mobs = append(g.mobs, enemy)
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWaveScript(t *testing.T) {
+	data := `waves:
+  - name: Opening
+    groups:
+      - mob: basic
+        count: 2
+        lane: north
+        spacing: 0.5
+      - mob: armored
+        count: 1
+        delay: 3
+        hp_scale: 2
+        modifiers:
+          shield: 2
+  - groups:
+      - mob: mixed
+        count: 4
+        loop_growth: 2
+loop:
+  from: 2
+  hp_growth: 0.5
+`
+	s, err := ParseWaveScript([]byte(data))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(s.Waves) != 2 || s.Waves[0].Name != "Opening" {
+		t.Fatalf("unexpected waves %+v", s.Waves)
+	}
+	g := s.Waves[0].Groups[0]
+	if g.Lane != "north" || g.Spacing != 0.5 {
+		t.Errorf("group fields not parsed: %+v", g)
+	}
+	if s.Waves[0].Groups[1].Modifiers.Shield != 2 {
+		t.Errorf("modifiers not parsed")
+	}
+
+	w, loops := s.Wave(4)
+	if loops != 2 || w.Groups[0].Mob != mobMixed {
+		t.Errorf("wave 4 should be the second loop of wave 2, got loops=%d", loops)
+	}
+}
+
+func TestWaveScriptValidation(t *testing.T) {
+	cases := map[string]string{
+		"no waves":    `waves: []`,
+		"no groups":   "waves:\n  - name: Empty\n",
+		"unknown mob": "waves:\n  - groups:\n      - {mob: dragon, count: 1}\n",
+		"zero count":  "waves:\n  - groups:\n      - {mob: basic, count: 0}\n",
+		"bad loop":    "waves:\n  - groups:\n      - {mob: basic, count: 1}\nloop:\n  from: 3\n",
+		"typo field":  "waves:\n  - groups:\n      - {mob: basic, cuont: 1}\n",
+		"flyer lane":  "waves:\n  - groups:\n      - {mob: flyer, count: 1, lane: north}\n",
+	}
+	for name, data := range cases {
+		if _, err := ParseWaveScript([]byte(data)); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+
+	// lanes are map names, checked once the map is known
+	bad, err := ParseWaveScript([]byte("waves:\n  - groups:\n      - {mob: basic, count: 1, lane: row40}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bad.CheckLanes(DefaultMap()) == nil {
+		t.Errorf("bad lane: expected validation error")
+	}
+}
+
+func TestReloadWavesKeepsScriptOnBadNames(t *testing.T) {
+	old := DefaultWaveScript(DefaultConfig)
+	g := &Game{cfg: &DefaultConfig, gameMap: DefaultMap(), bosses: DefaultBosses(), waveScript: old}
+	path := filepath.Join(t.TempDir(), WavesFile)
+	for name, data := range map[string]string{
+		"unknown lane": "waves:\n  - groups:\n      - {mob: basic, count: 1, lane: row40}\n",
+		"unknown boss": "waves:\n  - groups:\n      - {mob: boss, count: 1, boss: dragon}\n",
+	} {
+		os.WriteFile(path, []byte(data), 0644)
+		if err := g.reloadWaves(path); err == nil || g.waveScript != old {
+			t.Errorf("%s: reload should fail and keep the old script", name)
+		}
+	}
+	os.WriteFile(path, []byte("waves:\n  - groups:\n      - {mob: basic, count: 1}\n"), 0644)
+	if err := g.reloadWaves(path); err != nil || g.waveScript == old {
+		t.Errorf("a valid script should replace the old one: %v", err)
+	}
+}
+
+func TestDefaultWaveScriptSmallWaves(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.MobsPerWave, cfg.MobsPerWaveInc = 1, 0
+	if err := DefaultWaveScript(cfg).validate(); err != nil {
+		t.Errorf("small waves should still be valid: %v", err)
+	}
+}
+
+func TestDefaultWaveScriptMatchesGrowth(t *testing.T) {
+	g := &Game{cfg: &DefaultConfig}
+	for wave := 1; wave <= 10; wave++ {
+		g.currentWave = wave
+		g.scheduleWave()
+		want := DefaultConfig.MobsPerWave + DefaultConfig.MobsPerWaveInc*(wave-1)
+		if len(g.pendingSpawns) != want {
+			t.Errorf("wave %d: expected %d spawns got %d", wave, want, len(g.pendingSpawns))
+		}
+		last := g.pendingSpawns[len(g.pendingSpawns)-1]
+		if (last.group.Mob == "boss") != (wave%5 == 0) {
+			t.Errorf("wave %d: boss placement wrong, last mob %s", wave, last.group.Mob)
+		}
+	}
+}
+
+func TestWavesFileMatchesDefault(t *testing.T) {
+	s, err := LoadWaveScript("../../" + WavesFile)
+	if err != nil {
+		t.Fatalf("load %s: %v", WavesFile, err)
+	}
+	if !reflect.DeepEqual(s, DefaultWaveScript(DefaultConfig)) {
+		t.Errorf("%s differs from DefaultWaveScript", WavesFile)
+	}
+}
+
+func TestUpdateSpawnsHonoursSpacingAndDelay(t *testing.T) {
+	data := "waves:\n  - groups:\n      - {mob: basic, count: 1, spacing: 1}\n      - {mob: basic, count: 1, spacing: 1, delay: 2}\n"
+	s, err := ParseWaveScript([]byte(strings.TrimSpace(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Game{cfg: &DefaultConfig, currentWave: 1, base: NewBase(0, 0, 10)}
+	g.SetWaveScript(s)
+	g.updateSpawns(1)
+	if len(g.mobs) != 1 {
+		t.Fatalf("first mob should spawn after its spacing")
+	}
+	g.updateSpawns(2)
+	if len(g.mobs) != 1 {
+		t.Fatalf("second group should wait for its delay")
+	}
+	g.updateSpawns(1)
+	if len(g.mobs) != 2 {
+		t.Fatalf("second mob should spawn after delay plus spacing")
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import "math/rand"
+
+// ChallengeWords is the word library used for tower reload challenges. Words
+// are grouped roughly by the letter stage that makes them typeable.
+var ChallengeWords = []string{
+	// f j d k s l a
+	"flask", "salad", "falls", "skald", "lads", "adds",
+	// g h
+	"glass", "shall", "flash", "dash", "ghast",
+	// q p e i
+	"field", "shield", "pledge", "quiche", "spiked",
+	// r u t y
+	"rusty", "quarry", "turret", "studio", "tyrant",
+	// w o c m v n x z
+	"bronze", "vortex", "crown", "mortar", "wizard", "canyon",
+}
+
+// wordUsesPool reports whether every letter of word is present in pool.
+func wordUsesPool(word string, pool []rune) bool {
+	if len(pool) == 0 {
+		return false
+	}
+	avail := make(map[rune]struct{}, len(pool))
+	for _, r := range pool {
+		avail[r] = struct{}{}
+	}
+	for _, r := range word {
+		if _, ok := avail[r]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// wordsFromPool filters words down to those typeable with the given letters.
+func wordsFromPool(words []string, pool []rune) []string {
+	var out []string
+	for _, w := range words {
+		if wordUsesPool(w, pool) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// randomChallengeWord picks a library word typeable with the current letter
+// pool. When no library word fits, a pseudo-word is built from the pool so
+// early waves still get challenges. An empty string is returned if no
+// letters have been unlocked yet.
+func (g *Game) randomChallengeWord() string {
+	if len(g.letterPool) == 0 {
+		return ""
+	}
+	if words := wordsFromPool(ChallengeWords, g.letterPool); len(words) > 0 {
+		return words[rand.Intn(len(words))]
+	}
+	word := make([]rune, 5)
+	for i := range word {
+		word[i] = g.letterPool[rand.Intn(len(g.letterPool))]
+	}
+	return string(word)
+}
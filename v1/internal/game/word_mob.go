@@ -0,0 +1,64 @@
+package game
+
+// mobWord picks the word carried by a new word mob, using only unlocked
+// letters when possible.
+func (g *Game) mobWord() string {
+	if w := g.randomChallengeWord(); w != "" {
+		return w
+	}
+	return "fjfj"
+}
+
+// shootable reports whether towers and projectiles can hit the enemy. Word
+// mobs can only be defeated by typing their word.
+func shootable(e Enemy) bool {
+	return e.Type() != MobWord
+}
+
+// typeWordMobs feeds typed letters to word mobs and returns the letters left
+// for the queue. The first letter locks on to the word mob closest to the
+// base that starts with it; later letters advance that mob until it is typed
+// out. A wrong letter restarts the word and hands its letters on.
+func (g *Game) typeWordMobs(typed []rune) []rune {
+	var rest []rune
+	for _, r := range typed {
+		if m := g.wordTarget; m != nil {
+			if m.typeLetter(r) {
+				g.wordHeld = append(g.wordHeld, r)
+				if !m.Alive() {
+					g.wordTarget, g.wordHeld = nil, nil
+				}
+				continue
+			}
+			if m.Alive() {
+				rest = append(rest, g.wordHeld...)
+			}
+			g.wordTarget, g.wordHeld = nil, nil
+		}
+		if m := g.findWordTarget(r); m != nil {
+			m.typeLetter(r)
+			if m.Alive() {
+				g.wordTarget, g.wordHeld = m, []rune{r}
+			}
+			continue
+		}
+		rest = append(rest, r)
+	}
+	return rest
+}
+
+// findWordTarget returns the alive word mob nearest the base whose next
+// letter is r.
+func (g *Game) findWordTarget(r rune) *Mob {
+	var best *Mob
+	for _, e := range g.mobs {
+		m, ok := asMob(e)
+		if !ok || !m.Alive() || m.word == "" || rune(m.word[m.typed]) != r {
+			continue
+		}
+		if best == nil || m.pos.X < best.pos.X {
+			best = m
+		}
+	}
+	return best
+}
@@ -0,0 +1,56 @@
+package game
+
+import "testing"
+
+func TestTypeWordMobsLocksOnNearestMatch(t *testing.T) {
+	b := NewBase(0, 0, 1)
+	far := NewWordMob(800, 0, b, "jab", 0)
+	near := NewWordMob(300, 0, b, "jag", 0)
+	other := NewWordMob(100, 0, b, "fad", 0)
+	g := &Game{mobs: []Enemy{far, near, other}}
+
+	g.typeWordMobs([]rune("ja"))
+	if g.wordTarget != near {
+		t.Fatalf("should lock on the nearest mob starting with j")
+	}
+	if _, typed := near.Word(); typed != 2 {
+		t.Fatalf("expected 2 letters typed got %d", typed)
+	}
+	if _, typed := far.Word(); typed != 0 {
+		t.Errorf("only the locked mob should advance")
+	}
+
+	g.typeWordMobs([]rune("g"))
+	if near.Alive() {
+		t.Fatalf("locked mob should be typed out")
+	}
+	g.typeWordMobs([]rune("f"))
+	if g.wordTarget != other {
+		t.Errorf("next letter should pick a new target")
+	}
+}
+
+func TestWrongLettersRestartWordMob(t *testing.T) {
+	m := NewWordMob(100, 0, NewBase(0, 0, 1), "fad", 0)
+	g := &Game{mobs: []Enemy{m}}
+	if rest := g.typeWordMobs([]rune("fxaxd")); string(rest) != "fxaxd" {
+		t.Errorf("broken word letters should go on to the queue, got %q", string(rest))
+	}
+	if !m.Alive() || m.health != 3 {
+		t.Fatalf("interleaved wrong letters should not kill the mob, hp %d", m.health)
+	}
+	if rest := g.typeWordMobs([]rune("fad")); len(rest) != 0 || m.Alive() {
+		t.Errorf("typing the word should kill the mob and keep its letters, left %q", string(rest))
+	}
+}
+
+func TestTowersIgnoreWordMobs(t *testing.T) {
+	b := NewBase(0, 0, 1)
+	g := &Game{mobs: []Enemy{NewWordMob(10, 0, b, "fj", 0), NewMob(20, 0, b, 1, 0)}}
+	p := NewProjectile(g, 0, 0, g.mobs[1], 1, 1, 0)
+	for _, e := range p.candidates() {
+		if e.Type() == MobWord {
+			t.Fatalf("word mobs should not be projectile candidates")
+		}
+	}
+}